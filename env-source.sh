@@ -25,4 +25,4 @@ echo ""
 echo "Environment ready! You can now run:"
 echo "  go run . test           # Run instruction builder demos"
 echo "  go run .                # Parse real transactions"
-echo "  go test -v              # Run all tests"
+echo "  go test -v ./...         # Run all tests"
@@ -5,6 +5,7 @@ go 1.24.4
 require (
 	github.com/gagliardetto/binary v0.8.0
 	github.com/gagliardetto/solana-go v1.12.0
+	google.golang.org/protobuf v1.36.11
 )
 
 require (
@@ -3,8 +3,8 @@ package main
 import (
 	"context"
 	"encoding/base64"
-	"encoding/binary"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -13,144 +13,105 @@ import (
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
+
+	"raydium-parser/parser"
 )
 
 // Replace with a real Raydium swap transaction signature
 const realTxSignature = "2N9VyxzFmHibuWy5HmJH52R6Hy6NZPw5iCdFc9X1JT4JBPCa4VZmxv3RhSvP9UfDdCdgDYvoeaN62v29toJNAWtD"
 
+// RPC endpoints tried in turn until one returns the transaction
+var rpcEndpoints = []string{
+	rpc.MainNetBeta_RPC,
+	"https://solana-api.projectserum.com",
+	"https://api.mainnet-beta.solana.com",
+	"https://solana-mainnet.g.alchemy.com/v2/demo",
+}
+
 func main() {
+	debug := flag.Bool("debug", false, "print a breakdown of every instruction and its accounts")
+	lenient := flag.Bool("lenient", false, "guess unknown instructions instead of reporting them")
+	flag.Usage = printUsage
+	flag.Parse()
+
 	fmt.Println("Raydium Transaction Parser")
 	fmt.Println("==========================")
 
-	// Check command line arguments
-	if len(os.Args) > 1 {
-		switch os.Args[1] {
-		case "test":
-			fmt.Println("Test mode - running instruction builder tests...")
-			testInstructionBuilders()
-			return
-		case "debug":
-			fmt.Println("Debug mode - testing comprehensive debug output...")
-			testDebugOutput()
-			return
-		case "help", "-h", "--help":
-			printUsage()
-			return
-		case "offline":
-			fmt.Println("Running in offline mode...")
-			fmt.Println("Offline mode - functionality not implemented yet")
-			return
-		}
+	mode := parser.StrictParsing
+	if *lenient {
+		mode = parser.LenientParsing
 	}
-
-	fmt.Println("Fetching real transaction from Solana mainnet...")
-
-	// Try multiple RPC endpoints in case one fails
-	rpcEndpoints := []string{
-		rpc.MainNetBeta_RPC,
-		"https://solana-api.projectserum.com",
-		"https://api.mainnet-beta.solana.com",
-		"https://solana-mainnet.g.alchemy.com/v2/demo",
+	options := []parser.Option{parser.WithLogger(log.Default()), parser.WithParseMode(mode)}
+	if *debug {
+		options = append(options, parser.WithInstructionDebug(printInstructionDebugInfo))
 	}
+	p := parser.New(options...)
 
-	var txResp *rpc.GetTransactionResult
-	var client *rpc.Client
-	signature, err := solana.SignatureFromBase58(realTxSignature)
-	if err != nil {
-		log.Printf("Failed to parse signature: %v", err)
-		fmt.Println("Falling back to basic demo...")
-		demonstrateBasicFunctionality()
-		return
-	}
-
-	// Try each RPC endpoint
-	for i, endpoint := range rpcEndpoints {
-		fmt.Printf("Trying RPC endpoint %d/%d: %s\n", i+1, len(rpcEndpoints), endpoint)
-		client = rpc.New(endpoint)
-
-		// Create a context with timeout for each request
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-
-		txResp, err = client.GetTransaction(
-			ctx,
-			signature,
-			&rpc.GetTransactionOpts{
-				MaxSupportedTransactionVersion: &[]uint64{0}[0], // Support version 0 transactions
-				Encoding:                       "base64",
-			},
-		)
-
-		cancel() // Clean up the context
-
-		if err == nil && txResp != nil && txResp.Transaction != nil {
-			fmt.Printf("✅ Successfully fetched transaction from endpoint %d\n", i+1)
-			break
+	args := flag.Args()
+	switch {
+	case len(args) == 0:
+		signature, err := solana.SignatureFromBase58(realTxSignature)
+		if err != nil {
+			log.Fatalf("Failed to parse signature: %v", err)
 		}
-
-		log.Printf("❌ Endpoint %d failed: %v", i+1, err)
-		if i < len(rpcEndpoints)-1 {
-			fmt.Printf("Trying next endpoint...\n")
+		if !fetchAndParseTransaction(p, signature) {
+			os.Exit(1)
+		}
+	case args[0] == "help":
+		printUsage()
+	case args[0] == "test":
+		fmt.Println("Test mode - running instruction builder tests...")
+		testInstructionBuilders()
+	case args[0] == "file" && len(args) == 2:
+		if !loadAndParseFromFile(p, args[1]) {
+			os.Exit(1)
+		}
+	default:
+		signature, err := solana.SignatureFromBase58(args[0])
+		if err != nil {
+			printUsage()
+			os.Exit(2)
+		}
+		if !fetchAndParseTransaction(p, signature) {
+			os.Exit(1)
 		}
-	}
-	if err != nil || txResp == nil || txResp.Transaction == nil {
-		log.Printf("❌ All RPC endpoints failed. Last error: %v", err)
-		fmt.Println("Falling back to basic demo...")
-		demonstrateBasicFunctionality()
-		testWithRaydiumData()
-		// testWithMockRaydiumTransaction() // Temporarily disabled
-		return
-	}
-
-	// Get the base64 encoded transaction
-	encoded := txResp.Transaction.GetBinary()
-	slot := txResp.Slot
-
-	fmt.Println("Parsing transaction...")
-
-	transaction, err := ParseTransactionWithSignature(base64.StdEncoding.EncodeToString(encoded), slot, signature)
-	if err != nil {
-		fmt.Printf("Failed to parse transaction: %v\n", err)
-		demonstrateBasicFunctionality()
-		return
-	}
-
-	fmt.Printf("Transaction successfully parsed!\n\n")
-
-	issues := ValidateTransaction(transaction)
-	PrintValidationResults(issues)
-	fmt.Println()
-
-	AnalyzeTransaction(transaction)
-	printTransaction(transaction)
-
-	// Optional: Load another transaction from a file
-	if _, err := os.Stat("sample_transaction.txt"); err == nil {
-		fmt.Println("\nLoading transaction from file...")
-		loadAndParseFromFile("sample_transaction.txt")
 	}
 }
 
 // printUsage prints the usage information
 func printUsage() {
-	fmt.Println("Usage: raydium-parser [command]")
+	fmt.Println("Usage: raydium-parser [flags] [command]")
 	fmt.Println()
 	fmt.Println("Commands:")
-	fmt.Println("  test         Run all tests in offline mode")
-	fmt.Println("  offline      Run in offline mode (same as test)")
+	fmt.Println("  <signature>  Fetch and parse a transaction from Solana mainnet")
+	fmt.Println("  file <path>  Parse a base64 encoded transaction, or the signature, in a file")
+	fmt.Println("  test         Build sample instructions offline")
 	fmt.Println("  help         Show this help message")
-	fmt.Println("  (no args)    Fetch and parse a real transaction from Solana mainnet")
+	fmt.Println("  (no args)    Fetch and parse a sample transaction from Solana mainnet")
+	fmt.Println()
+	fmt.Println("Flags:")
+	flag.PrintDefaults()
 	fmt.Println()
 	fmt.Println("Examples:")
-	fmt.Println("  go run .                    # Fetch real transaction")
-	fmt.Println("  go run . test               # Run tests")
-	fmt.Println("  go run . offline            # Run in offline mode")
-	fmt.Println("  ./raydium-parser test       # Run tests (compiled)")
+	fmt.Println("  go run .                                # Fetch the sample transaction")
+	fmt.Println("  go run . -debug <signature>             # Fetch a transaction and dump its instructions")
+	fmt.Println("  go run . file sample_transaction.txt    # Parse a transaction from a file")
 }
 
 // printTransaction prints the transaction details in a formatted way
-func printTransaction(tx *Transaction) {
+func printTransaction(tx *parser.Transaction) {
 	fmt.Printf("Signature: %s\n", tx.Signature.String())
 	fmt.Printf("Slot: %d\n", tx.Slot)
+	fmt.Printf("Fee Payer: %s\n", tx.FeePayer.String())
+	fmt.Printf("Status: %s\n", tx.Status)
+	if tx.Err != nil {
+		fmt.Printf("Error: %s\n", tx.Err)
+	}
+	fmt.Printf("Fee: %d lamports (priority %d, %d CU consumed)\n",
+		tx.ComputeBudget.Fee, tx.ComputeBudget.PriorityFee, tx.ComputeBudget.ComputeUnitsConsumed)
+	if tx.JitoTip > 0 {
+		fmt.Printf("Jito Tip: %d lamports\n", tx.JitoTip)
+	}
 	fmt.Printf("Number of Creates: %d\n", len(tx.Create))
 	fmt.Printf("Number of Trades: %d\n", len(tx.Trade))
 	fmt.Printf("Number of Trade Buys: %d\n", len(tx.TradeBuys))
@@ -158,6 +119,19 @@ func printTransaction(tx *Transaction) {
 	fmt.Printf("Number of Migrations: %d\n", len(tx.Migrate))
 	fmt.Printf("Number of Swap Buys: %d\n", len(tx.SwapBuys))
 	fmt.Printf("Number of Swap Sells: %d\n", len(tx.SwapSells))
+	fmt.Printf("Number of Liquidity Adds: %d\n", len(tx.LiquidityAdds))
+	fmt.Printf("Number of Liquidity Removes: %d\n", len(tx.LiquidityRemoves))
+
+	if len(tx.Events) > 0 {
+		fmt.Println("\nEvents:")
+		for i, event := range tx.Events {
+			if event.InnerIndex >= 0 {
+				fmt.Printf("  [%d] %s at instruction %d.%d\n", i, event.Kind, event.InstructionIndex, event.InnerIndex)
+			} else {
+				fmt.Printf("  [%d] %s at instruction %d\n", i, event.Kind, event.InstructionIndex)
+			}
+		}
+	}
 
 	if len(tx.Create) > 0 {
 		fmt.Println("\nCreate Operations:")
@@ -173,6 +147,12 @@ func printTransaction(tx *Transaction) {
 			fmt.Printf("  [%d] Type: %s, TokenIn: %s, TokenOut: %s, Trader: %s, Pool: %s\n",
 				i, trade.TradeType, trade.TokenIn.String(), trade.TokenOut.String(),
 				trade.Trader.String(), trade.Pool.String())
+			if !trade.PlatformConfig.IsZero() {
+				fmt.Printf("      Platform: %s (%s)\n", trade.Platform, trade.PlatformConfig)
+			}
+			if trade.StackHeight > 1 {
+				fmt.Printf("      Inner instruction %d.%d (stack height %d)\n", trade.InstructionIndex, trade.InnerIndex, trade.StackHeight)
+			}
 		}
 	}
 
@@ -182,6 +162,10 @@ func printTransaction(tx *Transaction) {
 			fmt.Printf("  [%d] From: %s, To: %s, Token: %s, Owner: %s\n",
 				i, migration.FromPool.String(), migration.ToPool.String(),
 				migration.Token.String(), migration.Owner.String())
+			if !migration.LpMint.IsZero() {
+				fmt.Printf("      Base: %d, Quote: %d, LP: %d (burned: %t, locked: %t)\n",
+					migration.Amount, migration.QuoteAmount, migration.LpAmount, migration.LpBurned, migration.LpLocked)
+			}
 		}
 	}
 
@@ -203,6 +187,29 @@ func printTransaction(tx *Transaction) {
 		}
 	}
 
+	if len(tx.LiquidityAdds) > 0 {
+		fmt.Println("\nLiquidity Adds:")
+		for i, add := range tx.LiquidityAdds {
+			fmt.Printf("  [%d] Pool: %s, Provider: %s, Base: %d, Quote: %d, LP Minted: %d\n",
+				i, add.Pool.String(), add.Provider.String(), add.BaseAmount, add.QuoteAmount, add.LpMinted)
+		}
+	}
+
+	if len(tx.LiquidityRemoves) > 0 {
+		fmt.Println("\nLiquidity Removes:")
+		for i, remove := range tx.LiquidityRemoves {
+			fmt.Printf("  [%d] Pool: %s, Provider: %s, Base: %d, Quote: %d, LP Burned: %d\n",
+				i, remove.Pool.String(), remove.Provider.String(), remove.BaseAmount, remove.QuoteAmount, remove.LpBurned)
+		}
+	}
+
+	if len(tx.Diagnostics) > 0 {
+		fmt.Println("\nDiagnostics:")
+		for i, diagnostic := range tx.Diagnostics {
+			fmt.Printf("  [%d] %v\n", i, diagnostic)
+		}
+	}
+
 	// Pretty print as JSON for debugging
 	fmt.Println("\nJSON Representation:")
 	jsonData, err := json.MarshalIndent(tx, "", "  ")
@@ -213,17 +220,45 @@ func printTransaction(tx *Transaction) {
 	fmt.Println(string(jsonData))
 }
 
-// fetchAndParseTransaction fetches a transaction by signature and parses it
-func fetchAndParseTransaction(signature solana.Signature) bool {
-	// Try multiple RPC endpoints in case one fails
-	rpcEndpoints := []string{
-		rpc.MainNetBeta_RPC,
-		"https://solana-api.projectserum.com",
-		"https://api.mainnet-beta.solana.com",
-		"https://solana-mainnet.g.alchemy.com/v2/demo",
+// rawTransactionResult is a getTransaction result with the meta kept as raw JSON, since
+// rpc.TransactionMeta drops the stack height of inner instructions
+type rawTransactionResult struct {
+	Slot        uint64                         `json:"slot"`
+	BlockTime   *int64                         `json:"blockTime"`
+	Transaction *rpc.TransactionResultEnvelope `json:"transaction"`
+	Meta        json.RawMessage                `json:"meta"`
+}
+
+// blockTime returns the Unix time of the block, or 0 if the node didn't report it
+func (r *rawTransactionResult) blockTime() int64 {
+	if r.BlockTime == nil {
+		return 0
 	}
+	return *r.BlockTime
+}
 
-	var txResp *rpc.GetTransactionResult
+// getTransaction fetches a transaction (including v0) in base64 encoding
+func getTransaction(ctx context.Context, client *rpc.Client, signature solana.Signature) (*rawTransactionResult, error) {
+	var out *rawTransactionResult
+	err := client.RPCCallForInto(ctx, &out, "getTransaction", []interface{}{
+		signature.String(),
+		map[string]interface{}{
+			"encoding":                       "base64",
+			"maxSupportedTransactionVersion": 0, // Support version 0 transactions
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return nil, rpc.ErrNotFound
+	}
+	return out, nil
+}
+
+// fetchAndParseTransaction fetches a transaction by signature and parses it
+func fetchAndParseTransaction(p *parser.Parser, signature solana.Signature) bool {
+	var txResp *rawTransactionResult
 	var err error
 
 	// Try each RPC endpoint
@@ -233,27 +268,14 @@ func fetchAndParseTransaction(signature solana.Signature) bool {
 
 		// Create a context with timeout for each request
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-
-		txResp, err = client.GetTransaction(
-			ctx,
-			signature,
-			&rpc.GetTransactionOpts{
-				MaxSupportedTransactionVersion: &[]uint64{0}[0], // Support version 0 transactions
-				Encoding:                       "base64",
-			},
-		)
-
-		cancel() // Clean up the context
+		txResp, err = getTransaction(ctx, client, signature)
+		cancel()
 
 		if err == nil && txResp != nil && txResp.Transaction != nil {
 			fmt.Printf("✅ Successfully fetched transaction from endpoint %d\n", i+1)
 			break
 		}
-
 		log.Printf("❌ Endpoint %d failed: %v", i+1, err)
-		if i < len(rpcEndpoints)-1 {
-			fmt.Printf("Trying next endpoint...\n")
-		}
 	}
 
 	if err != nil || txResp == nil || txResp.Transaction == nil {
@@ -261,13 +283,15 @@ func fetchAndParseTransaction(signature solana.Signature) bool {
 		return false
 	}
 
-	// Get the base64 encoded transaction
-	encoded := txResp.Transaction.GetBinary()
-	slot := txResp.Slot
-
 	fmt.Println("Parsing transaction...")
 
-	transaction, err := ParseTransactionWithSignature(base64.StdEncoding.EncodeToString(encoded), slot, signature)
+	meta, err := parser.TransactionMetaFromJSON(txResp.Meta)
+	if err != nil {
+		log.Printf("Ignoring transaction meta: %v", err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(txResp.Transaction.GetBinary())
+	transaction, err := p.ParseTransactionWithMeta(encoded, txResp.Slot, txResp.blockTime(), signature, meta)
 	if err != nil {
 		fmt.Printf("Failed to parse transaction: %v\n", err)
 		return false
@@ -275,297 +299,45 @@ func fetchAndParseTransaction(signature solana.Signature) bool {
 
 	fmt.Printf("Transaction successfully parsed!\n\n")
 
-	issues := ValidateTransaction(transaction)
-	PrintValidationResults(issues)
+	parser.PrintValidationResults(os.Stdout, parser.ValidateTransaction(transaction))
 	fmt.Println()
 
-	AnalyzeTransaction(transaction)
+	parser.AnalyzeTransaction(os.Stdout, transaction)
 	printTransaction(transaction)
-
 	return true
 }
 
-// loadAndParseFromFile loads a transaction from a file and parses it
-func loadAndParseFromFile(filename string) {
+// loadAndParseFromFile parses the transaction in a file, which holds either its signature or
+// the base64 encoded transaction
+func loadAndParseFromFile(p *parser.Parser, filename string) bool {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		log.Printf("Error reading file %s: %v", filename, err)
-		return
+		return false
 	}
+	content := strings.TrimSpace(string(data))
 
-	content := string(data)
-	content = strings.TrimSpace(content) // Remove any whitespace/newlines
-
-	// Check if the content looks like a signature (base58) or transaction data (base64)
+	// A base58 signature is 87 or 88 characters long
 	if len(content) >= 80 && len(content) <= 90 {
-		// Likely a transaction signature - try to fetch it from RPC
 		fmt.Printf("File appears to contain a transaction signature: %s\n", content)
-		fmt.Printf("Attempting to fetch transaction from RPC...\n")
-
 		signature, err := solana.SignatureFromBase58(content)
 		if err != nil {
 			log.Printf("Invalid transaction signature: %v", err)
-			return
-		}
-
-		// Try to fetch the transaction using the same RPC logic as main
-		if !fetchAndParseTransaction(signature) {
-			log.Printf("Failed to fetch transaction with signature: %s", content)
+			return false
 		}
-		return
+		return fetchAndParseTransaction(p, signature)
 	}
 
-	// Assume the file contains a base64 encoded transaction
 	fmt.Printf("File appears to contain base64 transaction data\n")
-	slot := uint64(353025037) // Sample slot
-	transaction, err := ParseTransaction(content, slot)
+	transaction, err := p.ParseTransaction(content, 0, 0)
 	if err != nil {
 		log.Printf("Failed to parse transaction from file: %v", err)
-		return
+		return false
 	}
 
 	fmt.Printf("Transaction from file parsed successfully!\n")
 	printTransaction(transaction)
-
-	// Show comprehensive examples
-	testWithRaydiumData()
-	// testWithMockRaydiumTransaction() // Temporarily disabled
-}
-
-// demonstrateBasicFunctionality shows basic parser functionality
-func demonstrateBasicFunctionality() {
-	fmt.Println("=== Basic Parser Functionality Demo ===")
-
-	// Create a mock transaction to demonstrate parsing
-	mockSignature := solana.Signature{}
-	copy(mockSignature[:], []byte("demo_signature"))
-
-	tx := &Transaction{
-		Signature:  mockSignature,
-		Slot:       123456789,
-		Create:     []CreateInfo{},
-		Trade:      []TradeInfo{},
-		TradeBuys:  []int{},
-		TradeSells: []int{},
-		Migrate:    []Migration{},
-		SwapBuys:   []SwapBuy{},
-		SwapSells:  []SwapSell{},
-	}
-
-	// Add some mock data
-	mockTokenIn := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")   // SOL
-	mockTokenOut := solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v") // USDC
-
-	tradeInfo := TradeInfo{
-		InstructionIndex: 0,
-		TokenIn:          mockTokenIn,
-		TokenOut:         mockTokenOut,
-		AmountIn:         1000000000, // 1 SOL
-		AmountOut:        25000000,   // 25 USDC
-		Trader:           solana.MustPublicKeyFromBase58("11111111111111111111111111111111"),
-		Pool:             solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2"),
-		TradeType:        "swap",
-	}
-
-	tx.Trade = append(tx.Trade, tradeInfo)
-
-	fmt.Println("Demo transaction created successfully!")
-	printTransaction(tx)
-
-	// Test utility functions
-	fmt.Println("\n=== Testing Utility Functions ===")
-	fmt.Printf("SOL is base currency: %t\n", isBaseCurrency(mockTokenIn))
-	fmt.Printf("USDC is base currency: %t\n", isBaseCurrency(mockTokenOut))
-
-	// Test validation
-	fmt.Println("\n=== Testing Validation ===")
-	issues := ValidateTransaction(tx)
-	if len(issues) > 0 {
-		fmt.Printf("Validation found %d issues:\n", len(issues))
-		for _, issue := range issues {
-			fmt.Printf("- %s\n", issue)
-		}
-	} else {
-		fmt.Println("No validation issues found!")
-	}
-
-	fmt.Println("\n=== Demo Complete ===")
-}
-
-// testWithRaydiumData demonstrates parsing with crafted Raydium-like data
-func testWithRaydiumData() {
-	fmt.Println("\n=== Testing with Simulated Raydium Data ===")
-
-	// Create a transaction with simulated Raydium instructions
-	mockSignature := solana.Signature{}
-	copy(mockSignature[:], []byte("raydium_test_signature"))
-
-	result := &Transaction{
-		Signature:  mockSignature,
-		Slot:       123456789,
-		Create:     []CreateInfo{},
-		Trade:      []TradeInfo{},
-		TradeBuys:  []int{},
-		TradeSells: []int{},
-		Migrate:    []Migration{},
-		SwapBuys:   []SwapBuy{},
-		SwapSells:  []SwapSell{},
-	}
-
-	// Add mock token creation
-	createInfo := CreateInfo{
-		TokenMint:     solana.MustPublicKeyFromBase58("4k3Dyjzvzp8eMZWUXbBCjEvwSkkk59S5iCNLY3QrkX6R"),
-		PoolAddress:   solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2"),
-		Creator:       solana.MustPublicKeyFromBase58("7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"),
-		TokenDecimals: 9,
-		TokenSymbol:   "RAYTEST",
-		Amount:        1000000000000, // 1M tokens
-		Timestamp:     1700000000,
-	}
-	result.Create = append(result.Create, createInfo)
-
-	// Add mock swap (buy)
-	buyTrade := TradeInfo{
-		InstructionIndex: 1,
-		TokenIn:          solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112"),  // SOL
-		TokenOut:         solana.MustPublicKeyFromBase58("4k3Dyjzvzp8eMZWUXbBCjEvwSkkk59S5iCNLY3QrkX6R"), // New token
-		AmountIn:         500000000,                                                                      // 0.5 SOL
-		AmountOut:        1000000000,                                                                     // 1000 tokens
-		Trader:           solana.MustPublicKeyFromBase58("7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"),
-		Pool:             solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2"),
-		TradeType:        "buy",
-	}
-	result.Trade = append(result.Trade, buyTrade)
-	result.TradeBuys = append(result.TradeBuys, 1)
-
-	buySwap := SwapBuy{
-		TokenIn:      buyTrade.TokenIn,
-		TokenOut:     buyTrade.TokenOut,
-		AmountIn:     buyTrade.AmountIn,
-		AmountOut:    buyTrade.AmountOut,
-		Pool:         buyTrade.Pool,
-		Buyer:        buyTrade.Trader,
-		MinAmountOut: 950000000, // 950 tokens minimum
-		Slippage:     0.05,      // 5% slippage
-	}
-	result.SwapBuys = append(result.SwapBuys, buySwap)
-
-	// Add mock swap (sell)
-	sellTrade := TradeInfo{
-		InstructionIndex: 2,
-		TokenIn:          solana.MustPublicKeyFromBase58("4k3Dyjzvzp8eMZWUXbBCjEvwSkkk59S5iCNLY3QrkX6R"), // New token
-		TokenOut:         solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112"),  // SOL
-		AmountIn:         500000000,                                                                      // 500 tokens
-		AmountOut:        200000000,                                                                      // 0.2 SOL
-		Trader:           solana.MustPublicKeyFromBase58("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"),
-		Pool:             solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2"),
-		TradeType:        "sell",
-	}
-	result.Trade = append(result.Trade, sellTrade)
-	result.TradeSells = append(result.TradeSells, 2)
-
-	sellSwap := SwapSell{
-		TokenIn:      sellTrade.TokenIn,
-		TokenOut:     sellTrade.TokenOut,
-		AmountIn:     sellTrade.AmountIn,
-		AmountOut:    sellTrade.AmountOut,
-		Pool:         sellTrade.Pool,
-		Seller:       sellTrade.Trader,
-		MinAmountOut: 180000000, // 0.18 SOL minimum
-		Slippage:     0.10,      // 10% slippage
-	}
-	result.SwapSells = append(result.SwapSells, sellSwap)
-
-	// Add mock migration
-	migration := Migration{
-		FromPool:  solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2"),
-		ToPool:    solana.MustPublicKeyFromBase58("7XawhbbxtsRcQA8KTkHT9f9nc6d69UwqCDh6U5EEbEmX"),
-		Token:     solana.MustPublicKeyFromBase58("4k3Dyjzvzp8eMZWUXbBCjEvwSkkk59S5iCNLY3QrkX6R"),
-		Owner:     solana.MustPublicKeyFromBase58("7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU"),
-		Amount:    250000000, // 250 tokens
-		Timestamp: 1700000100,
-	}
-	result.Migrate = append(result.Migrate, migration)
-
-	fmt.Println("✅ Simulated Raydium transaction created successfully!")
-	printTransaction(result)
-}
-
-// testWithMockRaydiumTransaction tests with a crafted transaction containing Raydium-like binary data
-func testWithMockRaydiumTransaction() {
-	fmt.Println("\n=== Testing with Mock Raydium Transaction Data ===")
-
-	// Create a simple mock transaction with Raydium-like binary structure
-	// This simulates what actual Raydium transaction bytes might look like
-	mockTransactionBytes := make([]byte, 400) // Increased size to fix bounds issue
-
-	// Mock signature (64 bytes)
-	copy(mockTransactionBytes[0:64], []byte("mock_raydium_signature_for_testing_purposes_and_demonstration"))
-
-	// Mock message header (3 bytes)
-	mockTransactionBytes[64] = 1 // numSignatures
-	mockTransactionBytes[65] = 0 // numReadonlySignedAccounts
-	mockTransactionBytes[66] = 0 // numReadonlyUnsignedAccounts
-
-	// Mock account keys length (1 byte + account keys)
-	mockTransactionBytes[67] = 8 // 8 accounts
-
-	// Mock account keys (32 bytes each)
-	solPubkey := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
-	raydiumPubkey := solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
-
-	copy(mockTransactionBytes[68:100], solPubkey[:])
-	copy(mockTransactionBytes[100:132], raydiumPubkey[:])
-
-	// Fill remaining account keys with mock data
-	for i := 0; i < 6; i++ {
-		start := 132 + (i * 32)
-		copy(mockTransactionBytes[start:start+32], []byte(fmt.Sprintf("mock_account_%d_for_testing_purp", i)))
-	}
-	// Mock recent blockhash (32 bytes)
-	copy(mockTransactionBytes[324:356], []byte("mock_recent_blockhash_for_testing"))
-
-	// Mock instruction data
-	instructionStart := 260
-	mockTransactionBytes[instructionStart] = 1 // numInstructions
-
-	// Mock Raydium swap instruction
-	mockTransactionBytes[instructionStart+1] = 1 // programIdIndex (Raydium)
-	mockTransactionBytes[instructionStart+2] = 6 // numAccounts
-	// Account indices
-	mockTransactionBytes[instructionStart+3] = 0 // tokenIn
-	mockTransactionBytes[instructionStart+4] = 2 // tokenOut
-	mockTransactionBytes[instructionStart+5] = 3 // pool
-	mockTransactionBytes[instructionStart+6] = 4 // trader
-	mockTransactionBytes[instructionStart+7] = 5 // vault
-	mockTransactionBytes[instructionStart+8] = 6 // authority
-
-	// Mock instruction data
-	mockTransactionBytes[instructionStart+9] = 16 // data length
-	mockTransactionBytes[instructionStart+10] = 1 // instruction discriminator (swap)
-
-	// Mock amounts (8 bytes each)
-	amountIn := uint64(1000000000)    // 1 SOL
-	minAmountOut := uint64(950000000) // 950 tokens
-
-	binary.LittleEndian.PutUint64(mockTransactionBytes[instructionStart+11:instructionStart+19], amountIn)
-	binary.LittleEndian.PutUint64(mockTransactionBytes[instructionStart+19:instructionStart+27], minAmountOut)
-
-	// Encode to base64
-	encodedTx := base64.StdEncoding.EncodeToString(mockTransactionBytes)
-
-	fmt.Printf("Created mock transaction with %d bytes\n", len(mockTransactionBytes))
-	fmt.Printf("Encoded length: %d characters\n", len(encodedTx))
-
-	// Try to parse it
-	result, err := ParseTransaction(encodedTx, 987654321)
-	if err != nil {
-		fmt.Printf("Parser error: %v\n", err)
-		return
-	}
-
-	fmt.Println("✅ Mock Raydium transaction parsed successfully!")
-	printTransaction(result)
+	return true
 }
 
 // testInstructionBuilders tests the instruction builder functionality
@@ -574,7 +346,7 @@ func testInstructionBuilders() {
 
 	// Test Swap Instruction
 	fmt.Println("\n1. Testing Swap Instruction Builder:")
-	swapInst := NewSwapInstruction().
+	swapInst := parser.NewSwapInstruction().
 		SetUserSourceToken(solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")).
 		SetUserDestToken(solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")).
 		SetUserOwner(solana.MustPublicKeyFromBase58("HN7cABqLq46Es1jh92dQQisAq662SmxELLLsHHe4YWrH")).
@@ -596,7 +368,7 @@ func testInstructionBuilders() {
 
 	// Test Buy Instruction
 	fmt.Println("\n2. Testing Buy Instruction Builder:")
-	buyInst := NewBuyInstruction().
+	buyInst := parser.NewBuyInstruction().
 		SetUserAuthority(solana.MustPublicKeyFromBase58("HN7cABqLq46Es1jh92dQQisAq662SmxELLLsHHe4YWrH")).
 		SetTokenMint(solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")).
 		SetAmount(1000000).
@@ -617,7 +389,7 @@ func testInstructionBuilders() {
 
 	// Test Sell Instruction
 	fmt.Println("\n3. Testing Sell Instruction Builder:")
-	sellInst := NewSellInstruction().
+	sellInst := parser.NewSellInstruction().
 		SetUserAuthority(solana.MustPublicKeyFromBase58("HN7cABqLq46Es1jh92dQQisAq662SmxELLLsHHe4YWrH")).
 		SetTokenMint(solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")).
 		SetAmount(1000000).
@@ -638,7 +410,7 @@ func testInstructionBuilders() {
 
 	// Test Create Token Instruction
 	fmt.Println("\n4. Testing Create Token Instruction Builder:")
-	createInst := NewCreateTokenInstruction().
+	createInst := parser.NewCreateTokenInstruction().
 		SetPayer(solana.MustPublicKeyFromBase58("HN7cABqLq46Es1jh92dQQisAq662SmxELLLsHHe4YWrH")).
 		SetMint(solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")).
 		SetDecimals(6).
@@ -661,7 +433,7 @@ func testInstructionBuilders() {
 
 	// Test Migrate Instruction
 	fmt.Println("\n5. Testing Migrate Instruction Builder:")
-	migrateInst := NewMigrateInstruction().
+	migrateInst := parser.NewMigrateInstruction().
 		SetUserAuthority(solana.MustPublicKeyFromBase58("HN7cABqLq46Es1jh92dQQisAq662SmxELLLsHHe4YWrH")).
 		SetAmount(1000000)
 
@@ -681,52 +453,72 @@ func testInstructionBuilders() {
 	fmt.Println("\n✅ All instruction builder tests completed successfully!")
 	fmt.Println("\nNext steps:")
 	fmt.Println("- Set environment variables SOLANA_WALLET_PATH and SOLANA_RPC_ENDPOINT to test transaction submission")
-	fmt.Println("- Use 'go test -v' to run the full test suite")
+	fmt.Println("- Use 'go test -v ./...' to run the full test suite")
 	fmt.Println("- Run without arguments to test live transaction parsing")
 }
 
-// testDebugOutput tests the comprehensive debug output functionality
-func testDebugOutput() {
-	fmt.Println("Testing comprehensive debug output...")
-
-	// Create mock instruction
-	instruction := solana.CompiledInstruction{
-		ProgramIDIndex: 0,
-		Accounts:       []uint16{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17},
-		Data:           []byte{0xaf, 0xaf, 0x6d, 0x1f, 0x0d, 0x98, 0x9b, 0xed, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
-	}
-
-	// Create mock message with 18 accounts
-	message := &solana.Message{
-		AccountKeys: []solana.PublicKey{
-			solana.MustPublicKeyFromBase58("DcyrgE2gusF35moZDMVnjED7jfXBuQeJgjG2oEgocYWd"), // Creator/Signer
-			solana.MustPublicKeyFromBase58("8pf71rxkus6HVhNa9ERdJ571wfPa1a8QKKMsxGkDbonk"), // Token mint
-			solana.MustPublicKeyFromBase58("7ADJ8pYiWJA4gu2sC6VtXJ1EhbRzH4kavktmsHMfa91P"), // Pool
-			solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112"),  // SOL
-			solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"),  // Token program
-			solana.MustPublicKeyFromBase58("11111111111111111111111111111111"),             // System program
-			solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL"), // ATA program
-			solana.MustPublicKeyFromBase58("LanMV9sAd7wArD4vJFi2qDdfnVhFxYSUg6eADduJ3uj"),  // Launchpad program
-			solana.MustPublicKeyFromBase58("6s1xP3hpbAfFoNtUNF8mfHsjr2Bd97JxFJRWLbL6aHuX"), // User account 1
-			solana.MustPublicKeyFromBase58("WLHv2UAZm6z4KyaaELi5pjdbJh6RESMva1Rnn8pJVVh"),  // User account 2
-			solana.MustPublicKeyFromBase58("7q1ZvFbhCgRVyNBZQKCxGb7VHG3TdWDDJrFxFvELMkwS"), // User account 3
-			solana.MustPublicKeyFromBase58("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"), // User account 4
-			solana.MustPublicKeyFromBase58("8UviNr47S8eL32NjTcM8rEjXcCqfGJWTkNBJmL9PwqLH"), // User account 5
-			solana.MustPublicKeyFromBase58("6VtykzqrYmfHJ4xbKBQcwNGVGvP6MWVpKKgYxNsqRKzp"), // User account 6
-			solana.MustPublicKeyFromBase58("5SALHVLHLCQQmFYTdgJEBaBhBfKcVx5qLYGrBVWCqWcC"), // User account 7
-			solana.MustPublicKeyFromBase58("4oYJfEFPjjWEUAQqkUWRdQhLWKqXgNx6g7xHmKZvdTkt"), // User account 8
-			solana.MustPublicKeyFromBase58("3KSEjRqHyXjdWqbTz1X8MQbqQxFVpJjUPEGRBAfWfKJW"), // User account 9
-			solana.MustPublicKeyFromBase58("2JYPCXzTYhXvUgfwJyMvVoHGiGZVGaQVLdL3oKmKjPkT"), // User account 10
-		},
+// printInstructionDebugInfo prints the breakdown of an instruction the parser hands to its debug hook
+func printInstructionDebugInfo(debugInfo *parser.ComprehensiveInstructionDebug) {
+	fmt.Printf("\n%s\n", strings.Repeat("=", 100))
+	fmt.Printf("INSTRUCTION DEBUG INFO - Index: %d | Program: %s\n", debugInfo.InstructionIndex, debugInfo.ProgramName)
+	fmt.Printf("%s\n", strings.Repeat("=", 100))
+
+	// Print basic instruction info
+	fmt.Printf("Program ID: %s\n", debugInfo.ProgramID)
+	fmt.Printf("Discriminator: %s\n", debugInfo.Discriminator)
+	fmt.Printf("Data Length: %d bytes\n", debugInfo.DataLength)
+	fmt.Printf("Data Hex: %s\n", debugInfo.DataHex)
+	fmt.Printf("Account Count: %d\n", debugInfo.AccountCount)
+	fmt.Printf("Timestamp: %d\n", debugInfo.Timestamp)
+
+	// Print all account details
+	fmt.Printf("\nALL ACCOUNT DETAILS (18 fields per account):\n")
+	fmt.Printf("%s\n", strings.Repeat("-", 100))
+	for i, account := range debugInfo.Accounts {
+		fmt.Printf("Account %d:\n", i)
+		fmt.Printf("  Address: %s\n", account.Address)
+		fmt.Printf("  Description: %s\n", account.Description)
+		fmt.Printf("  Role: %s\n", account.Role)
+		fmt.Printf("  IsSystem: %t\n", account.IsSystem)
+		fmt.Printf("  IsProgram: %t\n", account.IsProgram)
+		fmt.Printf("  IsToken: %t\n", account.IsToken)
+		fmt.Printf("  IsSigner: %t\n", account.IsSigner)
+		fmt.Printf("  IsWritable: %t\n", account.IsWritable)
+		fmt.Printf("  IsExecutable: %t\n", account.IsExecutable)
+		fmt.Printf("  IsOwner: %t\n", account.IsOwner)
+		fmt.Printf("  IsRentExempt: %t\n", account.IsRentExempt)
+		fmt.Printf("  Balance: %d\n", account.Balance)
+		fmt.Printf("  DataSize: %d\n", account.DataSize)
+		fmt.Printf("  TokenMint: %s\n", account.TokenMint)
+		fmt.Printf("  TokenOwner: %s\n", account.TokenOwner)
+		fmt.Printf("  TokenAmount: %d\n", account.TokenAmount)
+		fmt.Printf("  TokenDecimals: %d\n", account.TokenDecimals)
+		fmt.Printf("%s\n", strings.Repeat("-", 50))
+	}
+
+	// Print parsed parameters
+	fmt.Printf("\nPARSED PARAMETERS:\n")
+	fmt.Printf("Amount: %d\n", debugInfo.Parameters.Amount)
+	fmt.Printf("AmountOut: %d\n", debugInfo.Parameters.AmountOut)
+	fmt.Printf("MaxAmount: %d\n", debugInfo.Parameters.MaxAmount)
+	fmt.Printf("MinAmount: %d\n", debugInfo.Parameters.MinAmount)
+	fmt.Printf("Decimals: %d\n", debugInfo.Parameters.Decimals)
+	fmt.Printf("Direction: %s\n", debugInfo.Parameters.Direction)
+	fmt.Printf("TokenIn: %s\n", debugInfo.Parameters.TokenIn)
+	fmt.Printf("TokenOut: %s\n", debugInfo.Parameters.TokenOut)
+	fmt.Printf("Pool: %s\n", debugInfo.Parameters.Pool)
+	fmt.Printf("Creator: %s\n", debugInfo.Parameters.Creator)
+	fmt.Printf("Trader: %s\n", debugInfo.Parameters.Trader)
+	fmt.Printf("Slippage: %.4f\n", debugInfo.Parameters.Slippage)
+
+	// Print full JSON
+	fmt.Printf("\nFULL JSON OUTPUT:\n")
+	jsonData, err := json.MarshalIndent(debugInfo, "", "  ")
+	if err != nil {
+		log.Printf("Error marshaling debug info to JSON: %v", err)
+	} else {
+		fmt.Println(string(jsonData))
 	}
 
-	// Test the debug function
-	fmt.Println("Creating debug info...")
-	programID := message.AccountKeys[7] // Launchpad program
-	debugInfo := createInstructionDebugInfo(instruction, message, 0, programID)
-
-	fmt.Println("Printing debug info...")
-	printInstructionDebugInfo(debugInfo)
-
-	fmt.Println("Debug output test completed!")
+	fmt.Printf("\n%s\n", strings.Repeat("=", 100))
 }
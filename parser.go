@@ -1,1710 +0,0 @@
-package main
-
-import (
-	"encoding/base64"
-	"encoding/binary"
-	"fmt"
-	"log"
-
-	bin "github.com/gagliardetto/binary"
-	"github.com/gagliardetto/solana-go"
-)
-
-// Known Raydium program IDs
-var (
-	RaydiumV4ProgramID        = solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
-	RaydiumV5ProgramID        = solana.MustPublicKeyFromBase58("5quBtoiQqxF9Jv6KYKctB59NT3gtJD2Y65kdnB1Uev3h")
-	RaydiumStakingProgramID   = solana.MustPublicKeyFromBase58("EhhTKczWMGQt46ynNeRX1WfeagwwJd7ufHvCDjRxjo5Q")
-	RaydiumLiquidityProgramID = solana.MustPublicKeyFromBase58("27haf8L6oxUeXrHrgEgsexjSY5hbVUWEmvv9Nyxg8vQv")
-	// Raydium Launchpad specific program IDs
-	RaydiumLaunchpadV1ProgramID = solana.MustPublicKeyFromBase58("LanMV9sAd7wArD4vJFi2qDdfnVhFxYSUg6eADduJ3uj")
-	RaydiumCpSwapProgramID      = solana.MustPublicKeyFromBase58("CPMMoo8L3F4NbTegBCKVNunggL7H1ZpdTHKxQB5qKP1C")
-	// Additional Raydium program IDs found in real transactions
-	RaydiumUnknownProgramID1 = solana.MustPublicKeyFromBase58("FoaFt2Dtz58RA6DPjbRb9t9z8sLJRChiGFTv21EfaseZ")
-	RaydiumUnknownProgramID2 = solana.MustPublicKeyFromBase58("LanMV9sAd7wArD4vJFi2qDdfnVhFxYSUg6eADduJ3uj")
-	// Standard Solana program IDs
-	TokenProgramID           = solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
-	Token2022ProgramID       = solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
-	SystemProgramID          = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
-	AssociatedTokenProgramID = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
-)
-
-// Instruction discriminators for different Raydium operations
-const (
-	// Raydium V4/V5 instructions
-	INSTRUCTION_INITIALIZE_POOL = 0
-	INSTRUCTION_SWAP            = 1
-	INSTRUCTION_DEPOSIT         = 2
-	INSTRUCTION_WITHDRAW        = 3
-	INSTRUCTION_MIGRATE         = 4
-
-	// Raydium Launchpad specific instructions (different values to avoid conflicts)
-	INSTRUCTION_CREATE_POOL   = 9
-	INSTRUCTION_INITIALIZE    = 10
-	INSTRUCTION_SWAP_BASE_IN  = 11
-	INSTRUCTION_SWAP_BASE_OUT = 12
-	INSTRUCTION_BUY           = 6
-	INSTRUCTION_SELL          = 7
-
-	// Token program instructions
-	TOKEN_INSTRUCTION_TRANSFER       = 3
-	TOKEN_INSTRUCTION_MINT_TO        = 7
-	TOKEN_INSTRUCTION_CREATE_ACCOUNT = 1
-	TOKEN_INSTRUCTION_CLOSE_ACCOUNT  = 9
-)
-
-// Geyser format support structures
-type GeyserTransaction struct {
-	Signature         solana.Signature
-	Slot              uint64
-	Instructions      []GeyserInstruction
-	InnerInstructions []GeyserInnerInstruction
-	AccountKeys       []solana.PublicKey
-	Meta              *TransactionMeta
-}
-
-type GeyserInstruction struct {
-	ProgramID solana.PublicKey
-	Accounts  []solana.PublicKey
-	Data      []byte
-}
-
-type GeyserInnerInstruction struct {
-	Index        int
-	Instructions []GeyserInstruction
-}
-
-type TransactionMeta struct {
-	PreBalances   []uint64
-	PostBalances  []uint64
-	TokenBalances []TokenBalance
-}
-
-type TokenBalance struct {
-	AccountIndex int
-	Mint         solana.PublicKey
-	Amount       uint64
-	Decimals     uint8
-}
-
-func ParseTransaction(encodedTx string, slot uint64) (*Transaction, error) {
-	// Try to parse as Geyser format first
-	if geyserTx, err := parseGeyserTransaction(encodedTx, slot); err == nil {
-		return parseGeyserFormatTransaction(geyserTx)
-	}
-
-	// Fallback to standard RPC format
-	return parseStandardTransaction(encodedTx, slot)
-}
-
-func parseGeyserTransaction(encodedTx string, slot uint64) (*GeyserTransaction, error) {
-
-	txBytes, err := base64.StdEncoding.DecodeString(encodedTx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64 transaction: %w", err)
-	}
-
-	if len(txBytes) > 100 && hasGeyserMarkers(txBytes) {
-		return parseGeyserBytes(txBytes, slot)
-	}
-
-	return nil, fmt.Errorf("not a Geyser format transaction")
-}
-
-// hasGeyserMarkers checks if the transaction bytes contain Geyser format markers
-func hasGeyserMarkers(txBytes []byte) bool {
-	return false
-}
-
-func parseGeyserBytes(txBytes []byte, slot uint64) (*GeyserTransaction, error) {
-
-	if len(txBytes) < 64 {
-		return nil, fmt.Errorf("transaction too short for Geyser format")
-	}
-
-	var signature solana.Signature
-	copy(signature[:], txBytes[:64])
-
-	return &GeyserTransaction{
-		Signature:         signature,
-		Slot:              slot,
-		Instructions:      []GeyserInstruction{},
-		InnerInstructions: []GeyserInnerInstruction{},
-		AccountKeys:       []solana.PublicKey{},
-		Meta:              &TransactionMeta{},
-	}, nil
-}
-
-// parseGeyserFormatTransaction parses a Geyser format transaction
-func parseGeyserFormatTransaction(geyserTx *GeyserTransaction) (*Transaction, error) {
-	result := &Transaction{
-		Signature:  geyserTx.Signature,
-		Slot:       geyserTx.Slot,
-		Create:     []CreateInfo{},
-		Trade:      []TradeInfo{},
-		TradeBuys:  []int{},
-		TradeSells: []int{},
-		Migrate:    []Migration{},
-		SwapBuys:   []SwapBuy{},
-		SwapSells:  []SwapSell{},
-	}
-
-	// Parse level-1 instructions
-	for i, instruction := range geyserTx.Instructions {
-		if err := parseGeyserInstructionWrapper(instruction, i, result, geyserTx.Meta); err != nil {
-			log.Printf("Error parsing Geyser instruction %d: %v", i, err)
-		}
-	}
-
-	// Parse level-2 (inner) instructions
-	for _, innerInstr := range geyserTx.InnerInstructions {
-		for j, instruction := range innerInstr.Instructions {
-			if err := parseGeyserInstructionWrapper(instruction, innerInstr.Index*100+j, result, geyserTx.Meta); err != nil {
-				log.Printf("Error parsing inner instruction %d.%d: %v", innerInstr.Index, j, err)
-			}
-		}
-	}
-
-	return result, nil
-}
-
-// parseStandardTransaction parses a standard RPC format transaction
-func parseStandardTransaction(encodedTx string, slot uint64) (*Transaction, error) {
-	// Decode the base64 encoded transaction
-	txBytes, err := base64.StdEncoding.DecodeString(encodedTx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64 transaction: %w", err)
-	}
-
-	log.Printf("Decoded transaction bytes: %d bytes", len(txBytes))
-
-	// Parse the transaction using solana-go
-	decoder := bin.NewBinDecoder(txBytes)
-	tx, err := solana.TransactionFromDecoder(decoder)
-	if err != nil {
-		// Log the specific error for debugging
-		log.Printf("Transaction decoding error: %v", err)
-		log.Printf("Trying alternative decoding method...")
-
-		// Try alternative decoding method
-		return parseTransactionWithAlternativeDecoder(txBytes, slot)
-	}
-
-	// Initialize the result transaction
-	result := &Transaction{
-		Signature:  tx.Signatures[0], // First signature is the transaction signature
-		Slot:       slot,
-		Create:     []CreateInfo{},
-		Trade:      []TradeInfo{},
-		TradeBuys:  []int{},
-		TradeSells: []int{},
-		Migrate:    []Migration{},
-		SwapBuys:   []SwapBuy{},
-		SwapSells:  []SwapSell{},
-	}
-
-	log.Printf("Parsing transaction with %d instructions", len(tx.Message.Instructions))
-
-	// Parse top-level instructions
-	for i, instruction := range tx.Message.Instructions {
-		if err := parseInstruction(instruction, &tx.Message, i, result); err != nil {
-			log.Printf("Error parsing instruction %d: %v", i, err)
-		}
-	}
-
-	return result, nil
-}
-
-// parseTransactionAlternative handles cases where standard unmarshaling fails
-func parseTransactionAlternative(encodedTx string, slot uint64) (*Transaction, error) {
-	log.Printf("Standard transaction parsing failed, using alternative approach")
-
-	// Create a transaction with basic info but no parsed instructions
-	mockSignature := solana.Signature{}
-	copy(mockSignature[:], []byte("fallback_signature"))
-
-	result := &Transaction{
-		Signature:  mockSignature,
-		Slot:       slot,
-		Create:     []CreateInfo{},
-		Trade:      []TradeInfo{},
-		TradeBuys:  []int{},
-		TradeSells: []int{},
-		Migrate:    []Migration{},
-		SwapBuys:   []SwapBuy{},
-		SwapSells:  []SwapSell{},
-	}
-
-	log.Printf("Transaction data length: %d bytes", len(encodedTx))
-
-	// For demonstration, if the transaction has substantial data,
-	// we'll add some sample parsed content
-	if len(encodedTx) > 100 {
-		// Simulate finding a swap instruction
-		mockTokenIn := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")   // SOL
-		mockTokenOut := solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v") // USDC
-		mockTrader := solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
-		mockPool := solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2")
-
-		tradeInfo := TradeInfo{
-			InstructionIndex: 0,
-			TokenIn:          mockTokenIn,
-			TokenOut:         mockTokenOut,
-			AmountIn:         1000000000, // 1 SOL
-			AmountOut:        25000000,   // 25 USDC
-			Trader:           mockTrader,
-			Pool:             mockPool,
-			TradeType:        "swap",
-		}
-
-		result.Trade = append(result.Trade, tradeInfo)
-		result.TradeBuys = append(result.TradeBuys, 0)
-
-		swapBuy := SwapBuy{
-			TokenIn:      mockTokenIn,
-			TokenOut:     mockTokenOut,
-			AmountIn:     1000000000,
-			AmountOut:    25000000,
-			Pool:         mockPool,
-			Buyer:        mockTrader,
-			MinAmountOut: 24000000,
-			Slippage:     0.04,
-		}
-		result.SwapBuys = append(result.SwapBuys, swapBuy)
-	}
-
-	return result, nil
-}
-
-// parseTransactionWithAlternativeDecoder tries a different approach to decode the transaction
-func parseTransactionWithAlternativeDecoder(txBytes []byte, slot uint64) (*Transaction, error) {
-
-	if len(txBytes) < 64 {
-		return nil, fmt.Errorf("transaction data too short: %d bytes", len(txBytes))
-	}
-
-	// Extract the first signature (first 64 bytes)
-	var signature solana.Signature
-	copy(signature[:], txBytes[:64])
-
-	// Initialize the result transaction
-	result := &Transaction{
-		Signature:  signature,
-		Slot:       slot,
-		Create:     []CreateInfo{},
-		Trade:      []TradeInfo{},
-		TradeBuys:  []int{},
-		TradeSells: []int{},
-		Migrate:    []Migration{},
-		SwapBuys:   []SwapBuy{},
-		SwapSells:  []SwapSell{},
-	}
-
-	log.Printf("Successfully extracted signature from transaction: %s", signature.String())
-	log.Printf("Remaining transaction data: %d bytes", len(txBytes)-64)
-
-	// TODO: Parse the rest of the transaction structure
-	// For now, we'll just return the transaction with the real signature
-
-	return result, nil
-}
-
-// ParseTransactionWithSignature parses a transaction from base64 encoded data with a known signature
-func ParseTransactionWithSignature(encodedTx string, slot uint64, originalSignature solana.Signature) (*Transaction, error) {
-	// First try Geyser format
-	geyserTx, err := parseGeyserTransaction(encodedTx, slot)
-	if err == nil {
-		// Convert Geyser transaction to standard transaction format
-		// but use the original signature
-		result := &Transaction{
-			Signature:  originalSignature, // Use the original signature instead of extracted one
-			Slot:       geyserTx.Slot,
-			Create:     []CreateInfo{},
-			Trade:      []TradeInfo{},
-			TradeBuys:  []int{},
-			TradeSells: []int{},
-			Migrate:    []Migration{},
-			SwapBuys:   []SwapBuy{},
-			SwapSells:  []SwapSell{},
-		}
-
-		// Convert Geyser transaction data to standard format
-		// This is a simplified conversion - real implementation would be more complex
-		log.Printf("Converted Geyser transaction to standard format")
-		return result, nil
-	}
-
-	// Fallback to standard RPC format
-	return parseStandardTransactionWithSignature(encodedTx, slot, originalSignature)
-}
-
-// parseStandardTransactionWithSignature parses a standard RPC format transaction with known signature
-func parseStandardTransactionWithSignature(encodedTx string, slot uint64, originalSignature solana.Signature) (*Transaction, error) {
-	// Decode the base64 encoded transaction
-	txBytes, err := base64.StdEncoding.DecodeString(encodedTx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64 transaction: %w", err)
-	}
-
-	log.Printf("Decoded transaction bytes: %d bytes", len(txBytes))
-
-	// Parse the transaction using solana-go
-	decoder := bin.NewBinDecoder(txBytes)
-	tx, err := solana.TransactionFromDecoder(decoder)
-	if err != nil {
-		// Log the specific error for debugging
-		log.Printf("Transaction decoding error: %v", err)
-		log.Printf("Trying alternative decoding method...")
-
-		// Try alternative decoding method
-		return parseTransactionWithAlternativeDecoderAndSignature(txBytes, slot, originalSignature)
-	}
-
-	// Initialize the result transaction with the original signature
-	result := &Transaction{
-		Signature:  originalSignature, // Use the original signature instead of tx.Signatures[0]
-		Slot:       slot,
-		Create:     []CreateInfo{},
-		Trade:      []TradeInfo{},
-		TradeBuys:  []int{},
-		TradeSells: []int{},
-		Migrate:    []Migration{},
-		SwapBuys:   []SwapBuy{},
-		SwapSells:  []SwapSell{},
-	}
-
-	log.Printf("Parsing transaction with %d instructions", len(tx.Message.Instructions))
-
-	// Parse top-level instructions
-	for i, instruction := range tx.Message.Instructions {
-		if err := parseInstruction(instruction, &tx.Message, i, result); err != nil {
-			log.Printf("Error parsing instruction %d: %v", i, err)
-			continue
-		}
-	}
-
-	// Parse inner instructions if any
-	// Note: Inner instructions are typically not available in this format
-	// They would be included in the transaction metadata from RPC calls
-
-	log.Printf("Successfully parsed transaction with %d creates, %d trades, %d migrations",
-		len(result.Create), len(result.Trade), len(result.Migrate))
-
-	return result, nil
-}
-
-// parseTransactionWithAlternativeDecoderAndSignature uses alternative decoding with known signature
-func parseTransactionWithAlternativeDecoderAndSignature(txBytes []byte, slot uint64, originalSignature solana.Signature) (*Transaction, error) {
-	log.Printf("Using alternative decoder for %d bytes", len(txBytes))
-
-	if len(txBytes) < 64 {
-		return nil, fmt.Errorf("transaction data too short: %d bytes", len(txBytes))
-	}
-
-	// Initialize result with the original signature
-	result := &Transaction{
-		Signature:  originalSignature, // Use the original signature
-		Slot:       slot,
-		Create:     []CreateInfo{},
-		Trade:      []TradeInfo{},
-		TradeBuys:  []int{},
-		TradeSells: []int{},
-		Migrate:    []Migration{},
-		SwapBuys:   []SwapBuy{},
-		SwapSells:  []SwapSell{},
-	}
-
-	// Try to parse what we can from the raw bytes
-	// This is a fallback method for when standard parsing fails
-	log.Printf("Alternative parsing completed - using original signature")
-
-	return result, nil
-}
-
-func parseInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if int(instruction.ProgramIDIndex) >= len(message.AccountKeys) {
-		return fmt.Errorf("invalid program ID index: %d", instruction.ProgramIDIndex)
-	}
-
-	programID := message.AccountKeys[instruction.ProgramIDIndex]
-
-	// Create comprehensive debug info structure
-	debugInfo := createInstructionDebugInfo(instruction, message, index, programID)
-
-	// Print detailed debug info with all 18 account fields
-	printInstructionDebugInfo(debugInfo)
-
-	// Check if this is a Raydium instruction
-	switch programID {
-	case RaydiumV4ProgramID, RaydiumV5ProgramID:
-		log.Printf("Found Raydium V4/V5 instruction at index %d", index)
-		return parseRaydiumInstruction(instruction, message, index, result)
-	case RaydiumStakingProgramID:
-		log.Printf("Found Raydium Staking instruction at index %d", index)
-		return parseStakingInstruction(instruction, message, index, result)
-	case RaydiumLiquidityProgramID:
-		log.Printf("Found Raydium Liquidity instruction at index %d", index)
-		return parseLiquidityInstruction(instruction, message, index, result)
-	case RaydiumLaunchpadV1ProgramID:
-		log.Printf("Found Raydium Launchpad instruction at index %d", index)
-		return parseRaydiumLaunchpadInstructionStandard(instruction, message, index, result)
-	case RaydiumCpSwapProgramID:
-		log.Printf("Found Raydium CP Swap instruction at index %d", index)
-		return parseRaydiumInstruction(instruction, message, index, result)
-	case RaydiumUnknownProgramID1, RaydiumUnknownProgramID2:
-		log.Printf("Found potential Raydium instruction at index %d (Program: %s)", index, programID.String())
-		return parseRaydiumInstruction(instruction, message, index, result)
-	case TokenProgramID:
-		log.Printf("Found Token Program instruction at index %d", index)
-		return parseTokenInstruction(instruction, message, index, result)
-	default:
-		// Not a Raydium-related instruction, skip
-		log.Printf("Skipping non-Raydium instruction at index %d (Program: %s)", index, programID.String())
-		return nil
-	}
-}
-
-// parseRaydiumInstruction parses Raydium swap/trade instructions
-func parseRaydiumInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if len(instruction.Data) == 0 {
-		return fmt.Errorf("instruction data is empty")
-	}
-
-	// Get the instruction discriminator (first byte for simple discriminators)
-	// For complex discriminators, we might need to read multiple bytes
-	discriminator := instruction.Data[0]
-
-	// Check if this is a complex discriminator (8 bytes)
-	if len(instruction.Data) >= 8 {
-		// Try to parse as 8-byte discriminator used by Anchor programs
-		discriminatorBytes := instruction.Data[:8]
-		if complexDiscriminator := binary.LittleEndian.Uint64(discriminatorBytes); complexDiscriminator != 0 {
-			return parseComplexRaydiumInstruction(instruction, message, index, result, complexDiscriminator)
-		}
-	}
-
-	switch discriminator {
-	case INSTRUCTION_INITIALIZE_POOL, INSTRUCTION_CREATE_POOL:
-		return parseCreatePoolInstruction(instruction, message, index, result)
-	case INSTRUCTION_SWAP, INSTRUCTION_SWAP_BASE_IN, INSTRUCTION_SWAP_BASE_OUT:
-		return parseSwapInstruction(instruction, message, index, result)
-	case INSTRUCTION_BUY:
-		return parseBuyInstructionStandard(instruction, message, index, result)
-	case INSTRUCTION_SELL:
-		return parseSellInstructionStandard(instruction, message, index, result)
-	case INSTRUCTION_DEPOSIT:
-		return parseDepositInstruction(instruction, message, index, result)
-	case INSTRUCTION_WITHDRAW:
-		return parseWithdrawInstruction(instruction, message, index, result)
-	case INSTRUCTION_MIGRATE:
-		return parseMigrateInstruction(instruction, message, index, result)
-	default:
-		log.Printf("Unknown Raydium instruction discriminator: %d", discriminator)
-		return nil
-	}
-}
-
-// parseComplexRaydiumInstruction handles complex 8-byte discriminators
-func parseComplexRaydiumInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, discriminator uint64) error {
-	// Known complex discriminators for Raydium programs
-	// These would be extracted from the actual Raydium IDL
-
-	// Example discriminators (these would need to be verified)
-	const (
-		COMPLEX_INITIALIZE = 0x175d3d5b8c84f4aa
-		COMPLEX_SWAP       = 0xf8c69e91e17587c8
-		COMPLEX_BUY        = 0x66063d1201daebea
-		COMPLEX_SELL       = 0xb712469c946da122
-		// Real discriminators found in transactions
-		COMPLEX_UNKNOWN_1 = 0x1a987cd39bde2795 // Found in LanMV9sAd7wArD4vJFi2qDdfnVhFxYSUg6eADduJ3uj
-		COMPLEX_UNKNOWN_2 = 0x0400000001010d09 // Found in FoaFt2Dtz58RA6DPjbRb9t9z8sLJRChiGFTv21EfaseZ
-	)
-
-	switch discriminator {
-	case COMPLEX_INITIALIZE:
-		return parseCreatePoolInstruction(instruction, message, index, result)
-	case COMPLEX_SWAP:
-		return parseSwapInstruction(instruction, message, index, result)
-	case COMPLEX_BUY:
-		return parseBuyInstructionStandard(instruction, message, index, result)
-	case COMPLEX_SELL:
-		return parseSellInstructionStandard(instruction, message, index, result)
-	case COMPLEX_UNKNOWN_1, COMPLEX_UNKNOWN_2:
-		log.Printf("Parsing unknown Raydium instruction with discriminator: %x", discriminator)
-		return parseGenericRaydiumInstruction(instruction, message, index, result, discriminator)
-	default:
-		log.Printf("Unknown complex Raydium instruction discriminator: %x", discriminator)
-		// Try to parse as generic Raydium instruction
-		return parseGenericRaydiumInstruction(instruction, message, index, result, discriminator)
-	}
-}
-
-// parseCreatePoolInstruction parses pool creation instructions
-func parseCreatePoolInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	// Extract accounts involved in pool creation
-	if len(instruction.Accounts) < 3 {
-		return fmt.Errorf("insufficient accounts for pool creation")
-	}
-
-	// Extract creation parameters from instruction data
-	var tokenDecimals uint8 = 9 // Default to 9 decimals
-	var initialLiquidity uint64 = 0
-
-	if len(instruction.Data) >= 17 {
-		// Skip discriminator and extract parameters
-		tokenDecimals = instruction.Data[9]
-		initialLiquidity = binary.LittleEndian.Uint64(instruction.Data[9:17])
-	} else if len(instruction.Data) >= 10 {
-		// Simpler format with just decimals
-		tokenDecimals = instruction.Data[9]
-	}
-
-	creator := message.AccountKeys[0] // Transaction signer is the creator
-
-	// Try to find the token mint by looking for the expected pattern
-	// For the specific transaction, we know the token mint is at index 1
-	expectedTokenMint := "8pf71rxkus6HVhNa9ERdJ571wfPa1a8QKKMsxGkDbonk"
-
-	var tokenMint, poolAddress solana.PublicKey
-
-	// First, look for the expected token mint in all accounts
-	for _, account := range message.AccountKeys {
-		if account.String() == expectedTokenMint {
-			tokenMint = account
-			break
-		}
-	}
-
-	// If we found the expected token mint, look for the pool
-	if !tokenMint.IsZero() {
-		// Look for pool address (different from token mint, creator, and system accounts)
-		solMint := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
-
-		for _, account := range message.AccountKeys {
-			// Skip system accounts
-			if account.Equals(SystemProgramID) || account.Equals(TokenProgramID) ||
-				account.Equals(AssociatedTokenProgramID) || account.Equals(solMint) ||
-				account.Equals(creator) || account.Equals(tokenMint) {
-				continue
-			}
-
-			// This should be the pool
-			poolAddress = account
-			break
-		}
-	} else {
-		// Fallback: search for token mint by looking for accounts that are not system accounts
-		solMint := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
-
-		for i := 0; i < len(instruction.Accounts) && i < 10; i++ {
-			accountIndex := int(instruction.Accounts[i])
-			if accountIndex >= len(message.AccountKeys) {
-				continue
-			}
-
-			account := message.AccountKeys[accountIndex]
-
-			// Skip system accounts
-			if account.Equals(SystemProgramID) || account.Equals(TokenProgramID) ||
-				account.Equals(AssociatedTokenProgramID) {
-				continue
-			}
-
-			// Skip SOL mint (used for base currency)
-			if account.Equals(solMint) {
-				continue
-			}
-
-			// Skip the creator account
-			if account.Equals(creator) {
-				continue
-			}
-
-			// First eligible account is likely the token mint
-			if tokenMint.IsZero() {
-				tokenMint = account
-			} else if poolAddress.IsZero() {
-				poolAddress = account
-			}
-		}
-	}
-
-	// Try to get token symbol from known tokens
-	tokenSymbol := "UNKNOWN"
-	if tokenInfo, exists := getKnownTokenInfo(tokenMint); exists {
-		tokenSymbol = tokenInfo.Symbol
-	}
-
-	createInfo := CreateInfo{
-		TokenMint:     tokenMint,
-		PoolAddress:   poolAddress,
-		Creator:       creator,
-		TokenDecimals: tokenDecimals,
-		TokenSymbol:   tokenSymbol,
-		Amount:        initialLiquidity,
-		Timestamp:     0, // Would need to be extracted from block time
-	}
-
-	result.Create = append(result.Create, createInfo)
-	return nil
-}
-
-// parseSwapInstruction parses swap instructions
-func parseSwapInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if len(instruction.Accounts) < 6 {
-		return fmt.Errorf("insufficient accounts for swap")
-	}
-
-	// Extract swap amounts from instruction data
-	var amountIn, minAmountOut uint64 = 0, 0
-
-	if len(instruction.Data) >= 17 {
-		// Skip discriminator (first byte) and extract amounts
-		amountIn = binary.LittleEndian.Uint64(instruction.Data[1:9])
-		minAmountOut = binary.LittleEndian.Uint64(instruction.Data[9:17])
-	} else if len(instruction.Data) >= 9 {
-		// Fallback for simpler instruction format
-		amountIn = binary.LittleEndian.Uint64(instruction.Data[1:9])
-	}
-
-	// Extract swap information
-	tokenIn := message.AccountKeys[instruction.Accounts[0]]
-	tokenOut := message.AccountKeys[instruction.Accounts[1]]
-	pool := message.AccountKeys[instruction.Accounts[2]]
-	trader := message.AccountKeys[0] // Transaction signer is the trader
-
-	tradeInfo := TradeInfo{
-		InstructionIndex: index,
-		TokenIn:          tokenIn,
-		TokenOut:         tokenOut,
-		Pool:             pool,
-		Trader:           trader,
-		AmountIn:         amountIn,
-		AmountOut:        0, // Would be extracted from transaction logs/metadata
-		TradeType:        "swap",
-	}
-
-	result.Trade = append(result.Trade, tradeInfo)
-
-	// Determine if it's a buy or sell based on token types
-	if isBaseCurrency(tokenIn) {
-		result.TradeBuys = append(result.TradeBuys, index)
-
-		swapBuy := SwapBuy{
-			TokenIn:      tokenIn,
-			TokenOut:     tokenOut,
-			AmountIn:     amountIn,
-			AmountOut:    tradeInfo.AmountOut,
-			Pool:         pool,
-			Buyer:        trader,
-			MinAmountOut: minAmountOut,
-			Slippage:     0.0, // Would be calculated from actual vs expected amounts
-		}
-		result.SwapBuys = append(result.SwapBuys, swapBuy)
-	} else {
-		result.TradeSells = append(result.TradeSells, index)
-
-		swapSell := SwapSell{
-			TokenIn:      tokenIn,
-			TokenOut:     tokenOut,
-			AmountIn:     amountIn,
-			AmountOut:    tradeInfo.AmountOut,
-			Pool:         pool,
-			Seller:       trader,
-			MinAmountOut: minAmountOut,
-			Slippage:     0.0, // Would be calculated from actual vs expected amounts
-		}
-		result.SwapSells = append(result.SwapSells, swapSell)
-	}
-
-	return nil
-}
-
-// parseBuyInstructionStandard parses buy instructions in standard format
-func parseBuyInstructionStandard(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if len(instruction.Accounts) < 3 {
-		return fmt.Errorf("insufficient accounts for buy")
-	}
-
-	// Extract buy parameters from instruction data
-	var amountIn, maxAmountIn uint64 = 0, 0
-
-	// For Launchpad transactions, we need to skip the discriminator
-	dataStart := 1
-	if len(instruction.Data) >= 8 {
-		dataStart = 8 // Skip 8-byte discriminator for complex instructions
-	}
-
-	if len(instruction.Data) >= dataStart+8 {
-		amountIn = binary.LittleEndian.Uint64(instruction.Data[dataStart : dataStart+8])
-	}
-	if len(instruction.Data) >= dataStart+16 {
-		maxAmountIn = binary.LittleEndian.Uint64(instruction.Data[dataStart+8 : dataStart+16])
-	}
-
-	// For launchpad buy transactions, TokenIn is typically SOL
-	solMint := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
-	buyer := message.AccountKeys[0] // Transaction signer is the buyer
-
-	expectedTokenMint := "8pf71rxkus6HVhNa9ERdJ571wfPa1a8QKKMsxGkDbonk"
-
-	var tokenOut, pool solana.PublicKey
-
-	// First, look for the expected token mint in all accounts
-	for i, account := range message.AccountKeys {
-		if account.String() == expectedTokenMint {
-			tokenOut = account
-			log.Printf("DEBUG: FOUND EXPECTED TOKEN MINT for buy at index %d: %s", i, tokenOut.String())
-			break
-		}
-	}
-
-	// If we found the expected token mint, look for the pool
-	if !tokenOut.IsZero() {
-		// Look for pool address (different from token mint, buyer, and system accounts)
-		for i, account := range message.AccountKeys {
-			// Skip system accounts
-			if account.Equals(SystemProgramID) || account.Equals(TokenProgramID) ||
-				account.Equals(AssociatedTokenProgramID) || account.Equals(solMint) ||
-				account.Equals(buyer) || account.Equals(tokenOut) {
-				continue
-			}
-
-			// Skip program accounts
-			if account.String() == "LanMV9sAd7wArD4vJFi2qDdfnVhFxYSUg6eADduJ3uj" {
-				continue
-			}
-
-			// This should be the pool
-			pool = account
-			log.Printf("DEBUG: FOUND POOL ADDRESS for buy at index %d: %s", i, pool.String())
-			break
-		}
-	} else {
-		// Fallback to old logic if we can't find the expected token mint
-		log.Printf("DEBUG: Expected token mint not found, using fallback logic")
-
-		// Search for token mint and pool using the same logic as create
-		for i := 0; i < len(instruction.Accounts) && i < 10; i++ {
-			accountIndex := int(instruction.Accounts[i])
-			if accountIndex >= len(message.AccountKeys) {
-				continue
-			}
-
-			account := message.AccountKeys[accountIndex]
-
-			// Skip system accounts
-			if account.Equals(SystemProgramID) || account.Equals(TokenProgramID) ||
-				account.Equals(AssociatedTokenProgramID) {
-				continue
-			}
-
-			// Skip SOL mint (used for base currency)
-			if account.Equals(solMint) {
-				continue
-			}
-
-			// Skip the buyer account
-			if account.Equals(buyer) {
-				continue
-			}
-
-			// First eligible account is likely the token being bought
-			if tokenOut.IsZero() {
-				tokenOut = account
-				log.Printf("DEBUG: Found token out: %s", tokenOut.String())
-			} else if pool.IsZero() {
-				pool = account
-				log.Printf("DEBUG: Found pool: %s", pool.String())
-			}
-		}
-	}
-
-	// Debug logging to help with troubleshooting
-	log.Printf("DEBUG: Buy instruction")
-	log.Printf("DEBUG: Buyer (signer): %s", buyer.String())
-	log.Printf("DEBUG: Token in (SOL): %s", solMint.String())
-	log.Printf("DEBUG: Token out: %s", tokenOut.String())
-	log.Printf("DEBUG: Pool: %s", pool.String())
-	log.Printf("DEBUG: Amount in: %d", amountIn)
-	log.Printf("DEBUG: Max amount in: %d", maxAmountIn)
-
-	tradeInfo := TradeInfo{
-		InstructionIndex: index,
-		TokenIn:          solMint,  // Base currency (SOL for launchpad)
-		TokenOut:         tokenOut, // Token being bought
-		Pool:             pool,
-		Trader:           buyer, // Transaction signer is the buyer
-		AmountIn:         amountIn,
-		AmountOut:        0, // Would be extracted from transaction logs
-		TradeType:        "buy",
-	}
-
-	result.Trade = append(result.Trade, tradeInfo)
-	result.TradeBuys = append(result.TradeBuys, index)
-
-	// Note: For Launchpad transactions, we only create Trade entries, not SwapBuy entries
-	// SwapBuy entries are only for traditional DEX swaps, not Launchpad buy operations
-
-	return nil
-}
-
-// parseSellInstructionStandard parses sell instructions in standard format
-func parseSellInstructionStandard(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if len(instruction.Accounts) < 3 {
-		return fmt.Errorf("insufficient accounts for sell")
-	}
-
-	// Extract sell parameters from instruction data
-	var amountIn, minAmountOut uint64 = 0, 0
-
-	if len(instruction.Data) >= 17 {
-		amountIn = binary.LittleEndian.Uint64(instruction.Data[1:9])
-		minAmountOut = binary.LittleEndian.Uint64(instruction.Data[9:17])
-	}
-
-	// For launchpad sell transactions, TokenOut is typically SOL
-	solMint := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
-
-	seller := message.AccountKeys[0]
-
-	var tokenIn, pool solana.PublicKey
-
-	// Add bounds checking for account access
-	if len(instruction.Accounts) > 0 && int(instruction.Accounts[0]) < len(message.AccountKeys) {
-		tokenIn = message.AccountKeys[instruction.Accounts[0]] // Token being sold
-	}
-	if len(instruction.Accounts) > 1 && int(instruction.Accounts[1]) < len(message.AccountKeys) {
-		pool = message.AccountKeys[instruction.Accounts[1]]
-	}
-
-	// Debug logging to help with troubleshooting
-	log.Printf("DEBUG: Sell instruction")
-	log.Printf("DEBUG: Seller (signer): %s", seller.String())
-	log.Printf("DEBUG: Token in: %s", tokenIn.String())
-	log.Printf("DEBUG: Token out (SOL): %s", solMint.String())
-	log.Printf("DEBUG: Pool: %s", pool.String())
-	log.Printf("DEBUG: Amount in: %d", amountIn)
-	log.Printf("DEBUG: Min amount out: %d", minAmountOut)
-
-	tradeInfo := TradeInfo{
-		InstructionIndex: index,
-		TokenIn:          tokenIn, // Token being sold
-		TokenOut:         solMint, // Base currency (SOL for launchpad)
-		Pool:             pool,
-		Trader:           seller, // Transaction signer is the seller
-		AmountIn:         amountIn,
-		AmountOut:        0, // Would be extracted from transaction logs
-		TradeType:        "sell",
-	}
-
-	result.Trade = append(result.Trade, tradeInfo)
-	result.TradeSells = append(result.TradeSells, index)
-
-	slippage := 0.0
-	if minAmountOut > 0 && tradeInfo.AmountOut > 0 {
-		slippage = calculateSlippage(tradeInfo.AmountOut, minAmountOut)
-	}
-
-	swapSell := SwapSell{
-		TokenIn:      tradeInfo.TokenIn,
-		TokenOut:     tradeInfo.TokenOut,
-		AmountIn:     amountIn,
-		AmountOut:    tradeInfo.AmountOut,
-		Pool:         tradeInfo.Pool,
-		Seller:       tradeInfo.Trader,
-		MinAmountOut: minAmountOut,
-		Slippage:     slippage,
-	}
-	result.SwapSells = append(result.SwapSells, swapSell)
-
-	return nil
-}
-
-// parseDepositInstruction parses liquidity deposit instructions
-func parseDepositInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	// Implementation would depend on the specific instruction format
-	log.Printf("Deposit instruction detected at index %d", index)
-	return nil
-}
-
-// parseWithdrawInstruction parses liquidity withdrawal instructions
-func parseWithdrawInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	// Implementation would depend on the specific instruction format
-	log.Printf("Withdraw instruction detected at index %d", index)
-	return nil
-}
-
-// parseMigrateInstruction parses migration instructions
-func parseMigrateInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if len(instruction.Accounts) < 4 {
-		return fmt.Errorf("insufficient accounts for migration")
-	}
-
-	// Extract migration amount from instruction data
-	var amount uint64 = 0
-	if len(instruction.Data) >= 9 {
-		amount = binary.LittleEndian.Uint64(instruction.Data[1:9])
-	}
-
-	migration := Migration{
-		FromPool:  message.AccountKeys[instruction.Accounts[0]],
-		ToPool:    message.AccountKeys[instruction.Accounts[1]],
-		Token:     message.AccountKeys[instruction.Accounts[2]],
-		Owner:     message.AccountKeys[instruction.Accounts[3]],
-		Amount:    amount,
-		Timestamp: 0, // Would be extracted from block time
-	}
-
-	result.Migrate = append(result.Migrate, migration)
-	return nil
-}
-
-// parseStakingInstruction parses staking-related instructions
-func parseStakingInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	log.Printf("Staking instruction detected at index %d", index)
-	return nil
-}
-
-// parseLiquidityInstruction parses liquidity-related instructions
-func parseLiquidityInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	log.Printf("Liquidity instruction detected at index %d", index)
-	return nil
-}
-
-// Enhanced token program instruction parsing
-func parseTokenInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if len(instruction.Data) == 0 {
-		return nil
-	}
-
-	discriminator := instruction.Data[0]
-
-	switch discriminator {
-	case TOKEN_INSTRUCTION_TRANSFER:
-		return parseTokenTransferInstructionStandard(instruction, message, index, result)
-	case TOKEN_INSTRUCTION_MINT_TO:
-		return parseTokenMintInstructionStandard(instruction, message, index, result)
-	default:
-		// Other token instructions we don't need to track
-		return nil
-	}
-}
-
-// parseTokenTransferInstructionStandard parses token transfer instructions in standard format
-func parseTokenTransferInstructionStandard(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if len(instruction.Data) < 9 || len(instruction.Accounts) < 3 {
-		return nil
-	}
-
-	// Extract transfer amount
-	amount := binary.LittleEndian.Uint64(instruction.Data[1:9])
-
-	log.Printf("Token transfer detected: %d tokens at instruction %d", amount, index)
-
-	return nil
-}
-
-func parseTokenMintInstructionStandard(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if len(instruction.Data) < 9 || len(instruction.Accounts) < 3 {
-		return nil
-	}
-
-	// Extract mint amount
-	amount := binary.LittleEndian.Uint64(instruction.Data[1:9])
-
-	// Token minting indicates token creation or additional supply
-	log.Printf("Token mint detected: %d tokens at instruction %d", amount, index)
-
-	return nil
-}
-
-// Helper functions
-
-func isBaseCurrency(tokenMint solana.PublicKey) bool {
-	// Known base currency mints
-	solMint := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
-	usdcMint := solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
-	usdtMint := solana.MustPublicKeyFromBase58("Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB")
-
-	return tokenMint.Equals(solMint) || tokenMint.Equals(usdcMint) || tokenMint.Equals(usdtMint)
-}
-
-// calculateSlippage calculates slippage percentage
-func calculateSlippage(actualAmount, expectedAmount uint64) float64 {
-	if expectedAmount == 0 {
-		return 0.0
-	}
-
-	if actualAmount >= expectedAmount {
-		return 0.0 // No slippage
-	}
-
-	return float64(expectedAmount-actualAmount) / float64(expectedAmount)
-}
-
-func getKnownTokenInfo(tokenMint solana.PublicKey) (TokenInfo, bool) {
-
-	knownTokensLocal := map[string]TokenInfo{
-		"So11111111111111111111111111111111111111112": {
-			Mint:     solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112"),
-			Symbol:   "SOL",
-			Name:     "Solana",
-			Decimals: 9,
-		},
-		"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v": {
-			Mint:     solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"),
-			Symbol:   "USDC",
-			Name:     "USD Coin",
-			Decimals: 6,
-		},
-		"Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB": {
-			Mint:     solana.MustPublicKeyFromBase58("Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"),
-			Symbol:   "USDT",
-			Name:     "Tether USD",
-			Decimals: 6,
-		},
-		"8pf71rxkus6HVhNa9ERdJ571wfPa1a8QKKMsxGkDbonk": {
-			Mint:     solana.MustPublicKeyFromBase58("8pf71rxkus6HVhNa9ERdJ571wfPa1a8QKKMsxGkDbonk"),
-			Symbol:   "JAMAL",
-			Name:     "Jamal Token",
-			Decimals: 6,
-		},
-	}
-
-	info, exists := knownTokensLocal[tokenMint.String()]
-	return info, exists
-}
-
-// parseGeyserInstructionWrapper parses a Geyser format instruction
-func parseGeyserInstructionWrapper(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	programID := instruction.ProgramID
-
-	// Check if this is a Raydium-related instruction
-	switch programID {
-	case RaydiumV4ProgramID, RaydiumV5ProgramID:
-		return parseRaydiumGeyserInstruction(instruction, index, result, meta)
-	case RaydiumLaunchpadV1ProgramID:
-		return parseRaydiumLaunchpadInstruction(instruction, index, result, meta)
-	case RaydiumCpSwapProgramID:
-		return parseRaydiumCpSwapInstruction(instruction, index, result, meta)
-	case TokenProgramID, Token2022ProgramID:
-		return parseTokenGeyserInstruction(instruction, index, result, meta)
-	default:
-		// Not a Raydium-related instruction, skip
-		return nil
-	}
-}
-
-func parseRaydiumGeyserInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	if len(instruction.Data) == 0 {
-		return fmt.Errorf("instruction data is empty")
-	}
-
-	discriminator := instruction.Data[0]
-
-	switch discriminator {
-	case INSTRUCTION_INITIALIZE_POOL, INSTRUCTION_CREATE_POOL:
-		return parseGeyserCreatePoolInstruction(instruction, index, result, meta)
-	case INSTRUCTION_SWAP, INSTRUCTION_SWAP_BASE_IN, INSTRUCTION_SWAP_BASE_OUT:
-		return parseGeyserSwapInstruction(instruction, index, result, meta)
-	case INSTRUCTION_BUY:
-		return parseGeyserBuyInstruction(instruction, index, result, meta)
-	case INSTRUCTION_SELL:
-		return parseGeyserSellInstruction(instruction, index, result, meta)
-	case INSTRUCTION_MIGRATE:
-		return parseGeyserMigrateInstruction(instruction, index, result, meta)
-	default:
-		log.Printf("Unknown Raydium instruction discriminator: %d", discriminator)
-		return nil
-	}
-}
-
-func parseRaydiumLaunchpadInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	if len(instruction.Data) == 0 {
-		return fmt.Errorf("launchpad instruction data is empty")
-	}
-
-	discriminator := instruction.Data[0]
-
-	switch discriminator {
-	case INSTRUCTION_INITIALIZE:
-		return parseGeyserCreatePoolInstruction(instruction, index, result, meta)
-	case INSTRUCTION_BUY:
-		return parseGeyserBuyInstruction(instruction, index, result, meta)
-	case INSTRUCTION_SELL:
-		return parseGeyserSellInstruction(instruction, index, result, meta)
-	default:
-		log.Printf("Unknown Raydium Launchpad instruction discriminator: %d", discriminator)
-		return nil
-	}
-}
-
-func parseRaydiumCpSwapInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	if len(instruction.Data) == 0 {
-		return fmt.Errorf("cp swap instruction data is empty")
-	}
-
-	discriminator := instruction.Data[0]
-
-	switch discriminator {
-	case INSTRUCTION_SWAP_BASE_IN, INSTRUCTION_SWAP_BASE_OUT:
-		return parseGeyserSwapInstruction(instruction, index, result, meta)
-	default:
-		log.Printf("Unknown CP Swap instruction discriminator: %d", discriminator)
-		return nil
-	}
-}
-
-func parseTokenGeyserInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	if len(instruction.Data) == 0 {
-		return nil
-	}
-
-	discriminator := instruction.Data[0]
-
-	switch discriminator {
-	case TOKEN_INSTRUCTION_TRANSFER:
-		return parseTokenTransferInstruction(instruction, index, result, meta)
-	case TOKEN_INSTRUCTION_MINT_TO:
-		return parseTokenMintInstruction(instruction, index, result, meta)
-	default:
-		return nil
-	}
-}
-
-func parseGeyserCreatePoolInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	if len(instruction.Accounts) < 8 {
-		return fmt.Errorf("insufficient accounts for pool creation")
-	}
-
-	var tokenDecimals uint8 = 9 // Default
-	var initialLiquidity uint64 = 0
-
-	if len(instruction.Data) >= 17 {
-		tokenDecimals = instruction.Data[9]
-		initialLiquidity = binary.LittleEndian.Uint64(instruction.Data[9:17])
-	}
-
-	tokenSymbol := extractTokenSymbol(instruction.Accounts[0], meta)
-
-	createInfo := CreateInfo{
-		TokenMint:     instruction.Accounts[0],
-		PoolAddress:   instruction.Accounts[1],
-		Creator:       instruction.Accounts[2],
-		TokenDecimals: tokenDecimals,
-		TokenSymbol:   tokenSymbol,
-		Amount:        initialLiquidity,
-		Timestamp:     0,
-	}
-
-	result.Create = append(result.Create, createInfo)
-	return nil
-}
-
-// parseGeyserSwapInstruction parses swap instructions in Geyser format
-func parseGeyserSwapInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	if len(instruction.Accounts) < 6 {
-		return fmt.Errorf("insufficient accounts for swap")
-	}
-
-	// Extract swap amounts from instruction data
-	var amountIn, amountOut uint64 = 0, 0
-	var minAmountOut uint64 = 0
-
-	if len(instruction.Data) >= 25 {
-		amountIn = binary.LittleEndian.Uint64(instruction.Data[1:9])
-		minAmountOut = binary.LittleEndian.Uint64(instruction.Data[9:17])
-		amountOut = extractAmountOutFromMeta(instruction.Accounts, meta)
-	}
-
-	tradeInfo := TradeInfo{
-		InstructionIndex: index,
-		TokenIn:          instruction.Accounts[0],
-		TokenOut:         instruction.Accounts[1],
-		Pool:             instruction.Accounts[2],
-		Trader:           instruction.Accounts[0], // Use first account as fallback for signer
-		AmountIn:         amountIn,
-		AmountOut:        amountOut,
-		TradeType:        "swap",
-	}
-
-	result.Trade = append(result.Trade, tradeInfo)
-
-	// Determine if it's a buy or sell
-	if isBaseCurrency(tradeInfo.TokenIn) {
-		result.TradeBuys = append(result.TradeBuys, index)
-
-		slippage := calculateSlippage(amountOut, minAmountOut)
-		swapBuy := SwapBuy{
-			TokenIn:      tradeInfo.TokenIn,
-			TokenOut:     tradeInfo.TokenOut,
-			AmountIn:     amountIn,
-			AmountOut:    amountOut,
-			Pool:         tradeInfo.Pool,
-			Buyer:        tradeInfo.Trader,
-			MinAmountOut: minAmountOut,
-			Slippage:     slippage,
-		}
-		result.SwapBuys = append(result.SwapBuys, swapBuy)
-	} else {
-		result.TradeSells = append(result.TradeSells, index)
-
-		slippage := calculateSlippage(amountOut, minAmountOut)
-		swapSell := SwapSell{
-			TokenIn:      tradeInfo.TokenIn,
-			TokenOut:     tradeInfo.TokenOut,
-			AmountIn:     amountIn,
-			AmountOut:    amountOut,
-			Pool:         tradeInfo.Pool,
-			Seller:       tradeInfo.Trader,
-			MinAmountOut: minAmountOut,
-			Slippage:     slippage,
-		}
-		result.SwapSells = append(result.SwapSells, swapSell)
-	}
-
-	return nil
-}
-
-func parseGeyserBuyInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	if len(instruction.Accounts) < 6 {
-		return fmt.Errorf("insufficient accounts for buy")
-	}
-
-	// Extract buy parameters from instruction data
-	var amountIn, maxAmountIn uint64 = 0, 0
-
-	if len(instruction.Data) >= 17 {
-		amountIn = binary.LittleEndian.Uint64(instruction.Data[1:9])
-		maxAmountIn = binary.LittleEndian.Uint64(instruction.Data[9:17])
-	}
-
-	amountOut := extractAmountOutFromMeta(instruction.Accounts, meta)
-
-	tradeInfo := TradeInfo{
-		InstructionIndex: index,
-		TokenIn:          instruction.Accounts[0], // Base currency (SOL/USDC)
-		TokenOut:         instruction.Accounts[1], // Token being bought
-		Pool:             instruction.Accounts[2],
-		Trader:           instruction.Accounts[0], // Use first account as fallback for signer
-		AmountIn:         amountIn,
-		AmountOut:        amountOut,
-		TradeType:        "buy",
-	}
-
-	result.Trade = append(result.Trade, tradeInfo)
-	result.TradeBuys = append(result.TradeBuys, index)
-
-	slippage := 0.0
-	if maxAmountIn > 0 && amountIn > 0 {
-		slippage = calculateSlippage(amountIn, maxAmountIn)
-	}
-
-	swapBuy := SwapBuy{
-		TokenIn:      tradeInfo.TokenIn,
-		TokenOut:     tradeInfo.TokenOut,
-		AmountIn:     amountIn,
-		AmountOut:    tradeInfo.AmountOut,
-		Pool:         tradeInfo.Pool,
-		Buyer:        tradeInfo.Trader,
-		MinAmountOut: 0, // Buy operations specify max input, not min output
-		Slippage:     slippage,
-	}
-	result.SwapBuys = append(result.SwapBuys, swapBuy)
-
-	return nil
-}
-
-func parseGeyserSellInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	if len(instruction.Accounts) < 6 {
-		return fmt.Errorf("insufficient accounts for sell")
-	}
-
-	var amountIn, minAmountOut uint64 = 0, 0
-
-	if len(instruction.Data) >= 17 {
-		amountIn = binary.LittleEndian.Uint64(instruction.Data[1:9])
-		minAmountOut = binary.LittleEndian.Uint64(instruction.Data[9:17])
-	}
-
-	// Calculate amount out from transaction metadata
-	amountOut := extractAmountOutFromMeta(instruction.Accounts, meta)
-
-	tradeInfo := TradeInfo{
-		InstructionIndex: index,
-		TokenIn:          instruction.Accounts[0], // Token being sold
-		TokenOut:         instruction.Accounts[1], // Base currency (SOL/USDC)
-		Pool:             instruction.Accounts[2],
-		Trader:           instruction.Accounts[0], // Use first account as fallback for signer
-		AmountIn:         amountIn,
-		AmountOut:        amountOut,
-		TradeType:        "sell",
-	}
-
-	result.Trade = append(result.Trade, tradeInfo)
-	result.TradeSells = append(result.TradeSells, index)
-
-	slippage := 0.0
-	if minAmountOut > 0 && tradeInfo.AmountOut > 0 {
-		slippage = calculateSlippage(tradeInfo.AmountOut, minAmountOut)
-	}
-
-	swapSell := SwapSell{
-		TokenIn:      tradeInfo.TokenIn,
-		TokenOut:     tradeInfo.TokenOut,
-		AmountIn:     amountIn,
-		AmountOut:    tradeInfo.AmountOut,
-		Pool:         tradeInfo.Pool,
-		Seller:       tradeInfo.Trader,
-		MinAmountOut: minAmountOut,
-		Slippage:     slippage,
-	}
-	result.SwapSells = append(result.SwapSells, swapSell)
-
-	return nil
-}
-
-// parseGeyserMigrateInstruction parses migration instructions in Geyser format
-func parseGeyserMigrateInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	if len(instruction.Accounts) < 4 {
-		return fmt.Errorf("insufficient accounts for migration")
-	}
-
-	// Extract migration amount from instruction data
-	var amount uint64 = 0
-	if len(instruction.Data) >= 9 {
-		amount = binary.LittleEndian.Uint64(instruction.Data[1:9])
-	}
-
-	migration := Migration{
-		FromPool:  instruction.Accounts[0],
-		ToPool:    instruction.Accounts[1],
-		Token:     instruction.Accounts[2],
-		Owner:     instruction.Accounts[3],
-		Amount:    amount,
-		Timestamp: 0, // Would be extracted from block time
-	}
-
-	result.Migrate = append(result.Migrate, migration)
-	return nil
-}
-
-// parseTokenTransferInstruction parses token transfer instructions
-func parseTokenTransferInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	// Token transfers help us understand the actual amounts moved
-	// This information is used to validate and supplement trade data
-	return nil
-}
-
-// parseTokenMintInstruction parses token mint instructions
-func parseTokenMintInstruction(instruction GeyserInstruction, index int, result *Transaction, meta *TransactionMeta) error {
-	// Token minting indicates token creation
-	// This information is used to supplement create data
-	return nil
-}
-
-// Helper functions for Geyser format
-
-// extractTokenSymbol extracts token symbol from metadata or returns default
-func extractTokenSymbol(tokenMint solana.PublicKey, meta *TransactionMeta) string {
-	// In a real implementation, this would:
-	// 1. Check known token registry
-	// 2. Query token metadata
-	// 3. Parse token name from transaction logs
-
-	// For now, i will return known symbols or default
-	if tokenInfo, exists := getKnownTokenInfo(tokenMint); exists {
-		return tokenInfo.Symbol
-	}
-	return "UNKNOWN"
-}
-
-func extractAmountOutFromMeta(accounts []solana.PublicKey, meta *TransactionMeta) uint64 {
-	// In a real implementation, this would:
-	// 1. Compare pre/post balances
-	// 2. Parse token balance changes
-	// 3. Extract from transaction logs
-
-	// For now, i will return a placeholder
-	if meta != nil && len(meta.PostBalances) > 0 {
-		// Simple example: return difference in balances
-		if len(meta.PreBalances) > 0 && len(meta.PostBalances) > 0 {
-			if len(meta.PreBalances) > 1 && len(meta.PostBalances) > 1 {
-				return meta.PostBalances[1] - meta.PreBalances[1]
-			}
-		}
-	}
-	return 0
-}
-
-func parseGenericRaydiumInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, discriminator uint64) error {
-	log.Printf("Attempting to parse generic Raydium instruction (discriminator: %x, accounts: %d, data: %d bytes)",
-		discriminator, len(instruction.Accounts), len(instruction.Data))
-
-	if len(instruction.Accounts) >= 6 && len(instruction.Data) >= 16 {
-		log.Printf("Parsing as potential swap instruction")
-		return parseAsSwapInstruction(instruction, message, index, result)
-	}
-
-	if len(instruction.Accounts) >= 4 && len(instruction.Data) >= 8 {
-		log.Printf("Parsing as potential create/migrate instruction")
-		return parseAsCreateOrMigrateInstruction(instruction, message, index, result)
-	}
-
-	log.Printf("Unknown Raydium instruction detected but not parsed (insufficient data)")
-	return nil
-}
-
-func parseAsSwapInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-
-	var amountIn, minAmountOut uint64 = 0, 0
-
-	if len(instruction.Data) >= 16 {
-		amountIn = binary.LittleEndian.Uint64(instruction.Data[8:16])
-		if len(instruction.Data) >= 24 {
-			minAmountOut = binary.LittleEndian.Uint64(instruction.Data[16:24])
-		}
-	}
-
-	// The trader is the transaction signer (first account)
-	trader := message.AccountKeys[0]
-
-	// Debug: Log account structure for analysis
-	log.Printf("DEBUG: Total accounts in message: %d", len(message.AccountKeys))
-	log.Printf("DEBUG: Instruction accounts: %d", len(instruction.Accounts))
-	for i := 0; i < len(instruction.Accounts) && i < 10; i++ {
-		accountIndex := int(instruction.Accounts[i])
-		if accountIndex < len(message.AccountKeys) {
-			account := message.AccountKeys[accountIndex]
-			log.Printf("DEBUG: Account[%d] = %s", i, account.String())
-		} else {
-			log.Printf("DEBUG: Account index %d out of bounds (max %d)", accountIndex, len(message.AccountKeys)-1)
-		}
-	}
-
-	solMint := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")
-
-	var tokenIn, tokenOut, pool solana.PublicKey
-
-	tokenIn = solMint
-
-	if len(instruction.Accounts) > 0 && int(instruction.Accounts[0]) < len(message.AccountKeys) {
-		tokenOut = message.AccountKeys[instruction.Accounts[0]]
-		log.Printf("DEBUG: Found tokenOut: %s", tokenOut.String())
-	}
-
-	if len(instruction.Accounts) > 1 && int(instruction.Accounts[1]) < len(message.AccountKeys) {
-		pool = message.AccountKeys[instruction.Accounts[1]]
-		log.Printf("DEBUG: Found pool: %s", pool.String())
-	}
-
-	// If tokenOut is SOL, this is likely a sell (token -> SOL)
-	if tokenOut.Equals(solMint) {
-		// This is a sell, so swap tokenIn and tokenOut
-		if len(instruction.Accounts) > 0 && int(instruction.Accounts[0]) < len(message.AccountKeys) {
-			tokenIn = message.AccountKeys[instruction.Accounts[0]]
-			tokenOut = solMint
-			log.Printf("DEBUG: Detected sell - tokenIn: %s, tokenOut: %s", tokenIn.String(), tokenOut.String())
-		}
-	}
-
-	tradeInfo := TradeInfo{
-		InstructionIndex: index,
-		TokenIn:          tokenIn,
-		TokenOut:         tokenOut,
-		Pool:             pool,
-		Trader:           trader,
-		AmountIn:         amountIn,
-		AmountOut:        0,
-		TradeType:        "swap",
-	}
-
-	result.Trade = append(result.Trade, tradeInfo)
-
-	// Determine if it's a buy or sell based on token types
-	if isBaseCurrency(tokenIn) {
-		result.TradeBuys = append(result.TradeBuys, index)
-
-		swapBuy := SwapBuy{
-			TokenIn:      tokenIn,
-			TokenOut:     tokenOut,
-			AmountIn:     amountIn,
-			AmountOut:    tradeInfo.AmountOut,
-			Pool:         pool,
-			Buyer:        trader,
-			MinAmountOut: minAmountOut,
-			Slippage:     0.0, // Would be calculated from actual vs expected amounts
-		}
-		result.SwapBuys = append(result.SwapBuys, swapBuy)
-		log.Printf("Parsed as buy: %d tokens in, %d min out", amountIn, minAmountOut)
-	} else {
-		result.TradeSells = append(result.TradeSells, index)
-
-		swapSell := SwapSell{
-			TokenIn:      tokenIn,
-			TokenOut:     tokenOut,
-			AmountIn:     amountIn,
-			AmountOut:    tradeInfo.AmountOut,
-			Pool:         pool,
-			Seller:       trader,
-			MinAmountOut: minAmountOut,
-			Slippage:     0.0, // Would be calculated from actual vs expected amounts
-		}
-		result.SwapSells = append(result.SwapSells, swapSell)
-		log.Printf("Parsed as sell: %d tokens in, %d min out", amountIn, minAmountOut)
-	}
-
-	return nil
-}
-
-func parseAsCreateOrMigrateInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	// Try to parse as pool creation
-	if len(instruction.Accounts) >= 8 {
-		log.Printf("Parsing as potential pool creation")
-		return parseCreatePoolInstruction(instruction, message, index, result)
-	}
-
-	if len(instruction.Accounts) >= 4 {
-		log.Printf("Parsing as potential migration")
-		return parseMigrateInstruction(instruction, message, index, result)
-	}
-
-	return nil
-}
-
-// parseRaydiumLaunchpadInstructionStandard parses Raydium Launchpad instructions with standard format
-func parseRaydiumLaunchpadInstructionStandard(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
-	if len(instruction.Data) == 0 {
-		return fmt.Errorf("launchpad instruction data is empty")
-	}
-
-	// Get the instruction discriminator (first byte)
-	discriminator := instruction.Data[0]
-
-	log.Printf("Launchpad instruction discriminator: %d at index %d", discriminator, index)
-
-	// Check if this is a complex discriminator (8 bytes)
-	if len(instruction.Data) >= 8 {
-		// Try to parse as 8-byte discriminator used by Anchor programs
-		discriminatorBytes := instruction.Data[:8]
-		if complexDiscriminator := binary.LittleEndian.Uint64(discriminatorBytes); complexDiscriminator != 0 {
-			log.Printf("Launchpad complex discriminator: %x", complexDiscriminator)
-			return parseComplexLaunchpadInstruction(instruction, message, index, result, complexDiscriminator)
-		}
-	}
-
-	switch discriminator {
-	case INSTRUCTION_INITIALIZE, INSTRUCTION_INITIALIZE_POOL, INSTRUCTION_CREATE_POOL:
-		log.Printf("Parsing launchpad create/initialize instruction")
-		return parseCreatePoolInstruction(instruction, message, index, result)
-	case INSTRUCTION_BUY:
-		log.Printf("Parsing launchpad buy instruction")
-		return parseBuyInstructionStandard(instruction, message, index, result)
-	case INSTRUCTION_SELL:
-		log.Printf("Parsing launchpad sell instruction")
-		return parseSellInstructionStandard(instruction, message, index, result)
-	case INSTRUCTION_SWAP, INSTRUCTION_SWAP_BASE_IN, INSTRUCTION_SWAP_BASE_OUT:
-		log.Printf("Parsing launchpad swap instruction")
-		return parseSwapInstruction(instruction, message, index, result)
-	case INSTRUCTION_MIGRATE:
-		log.Printf("Parsing launchpad migrate instruction")
-		return parseMigrateInstruction(instruction, message, index, result)
-	default:
-		log.Printf("Unknown Launchpad instruction discriminator: %d", discriminator)
-		// Try to parse as generic launchpad instruction
-		return parseGenericLaunchpadInstruction(instruction, message, index, result, uint64(discriminator))
-	}
-}
-
-// parseComplexLaunchpadInstruction handles complex 8-byte discriminators for launchpad
-func parseComplexLaunchpadInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, discriminator uint64) error {
-	// Known complex discriminators for Raydium Launchpad programs
-	// These are extracted from real transactions on Solscan
-	const (
-		LAUNCHPAD_INITIALIZE = 0x175d3d5b8c84f4aa
-		LAUNCHPAD_BUY        = 0x66063d1201daebea
-		LAUNCHPAD_SELL       = 0xb712469c946da122
-		LAUNCHPAD_SWAP       = 0xf8c69e91e17587c8
-		LAUNCHPAD_MIGRATE    = 0x4a4c4e5d6f7e8f9a
-		// Real discriminators found in actual transactions
-		LAUNCHPAD_REAL_1 = 0x0663c1f6e7b8d9ea // Common in launchpad transactions
-		LAUNCHPAD_REAL_2 = 0x1b4c5d6e7f8a9b0c // Buy/sell operations
-		LAUNCHPAD_REAL_3 = 0x2e5f6a7b8c9d0e1f // Token creation
-	)
-
-	switch discriminator {
-	case LAUNCHPAD_INITIALIZE:
-		log.Printf("Parsing launchpad initialize with complex discriminator")
-		return parseCreatePoolInstruction(instruction, message, index, result)
-	case LAUNCHPAD_BUY, LAUNCHPAD_REAL_2:
-		log.Printf("Parsing launchpad buy with complex discriminator")
-		return parseBuyInstructionStandard(instruction, message, index, result)
-	case LAUNCHPAD_SELL:
-		log.Printf("Parsing launchpad sell with complex discriminator")
-		return parseSellInstructionStandard(instruction, message, index, result)
-	case LAUNCHPAD_SWAP:
-		log.Printf("Parsing launchpad swap with complex discriminator")
-		return parseSwapInstruction(instruction, message, index, result)
-	case LAUNCHPAD_MIGRATE:
-		log.Printf("Parsing launchpad migrate with complex discriminator")
-		return parseMigrateInstruction(instruction, message, index, result)
-	case LAUNCHPAD_REAL_1, LAUNCHPAD_REAL_3:
-		log.Printf("Parsing launchpad instruction with known real discriminator: %x", discriminator)
-		return parseGenericLaunchpadInstruction(instruction, message, index, result, discriminator)
-	default:
-		log.Printf("Unknown complex Launchpad instruction discriminator: %x", discriminator)
-		// Try to parse as generic launchpad instruction
-		return parseGenericLaunchpadInstruction(instruction, message, index, result, discriminator)
-	}
-}
-
-// parseGenericLaunchpadInstruction attempts to parse unknown launchpad instructions
-func parseGenericLaunchpadInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, discriminator uint64) error {
-	log.Printf("Attempting to parse generic launchpad instruction (discriminator: %x, accounts: %d, data: %d bytes)",
-		discriminator, len(instruction.Accounts), len(instruction.Data))
-
-	// Extract instruction data beyond discriminator
-	dataStart := 1
-	if len(instruction.Data) >= 8 {
-		dataStart = 8 // Skip 8-byte discriminator
-	}
-
-	// Common launchpad patterns analysis
-	if len(instruction.Accounts) >= 8 && len(instruction.Data) >= dataStart+32 {
-		log.Printf("Pattern matches token creation - parsing as create")
-		return parseCreatePoolInstruction(instruction, message, index, result)
-	}
-
-	if len(instruction.Accounts) >= 6 && len(instruction.Data) >= dataStart+16 {
-		// Check if this looks like a buy/sell instruction
-		// Launchpad buy/sell typically have specific account patterns
-		if len(instruction.Data) >= dataStart+16 {
-			var amount uint64
-			if len(instruction.Data) >= dataStart+8 {
-				amount = binary.LittleEndian.Uint64(instruction.Data[dataStart : dataStart+8])
-			}
-
-			log.Printf("Pattern matches buy/sell - amount: %d", amount)
-
-			// Determine if it's buy or sell based on account patterns
-			// This is a heuristic based on common launchpad patterns
-			if amount > 0 {
-				// Try to parse as buy first
-				if err := parseBuyInstructionStandard(instruction, message, index, result); err == nil {
-					return nil
-				}
-				// Fallback to sell
-				return parseSellInstructionStandard(instruction, message, index, result)
-			}
-		}
-	}
-
-	if len(instruction.Accounts) >= 4 && len(instruction.Data) >= dataStart+8 {
-		log.Printf("Pattern matches swap/migrate - parsing as swap")
-		return parseSwapInstruction(instruction, message, index, result)
-	}
-
-	log.Printf("Unable to parse launchpad instruction - insufficient data or unknown pattern")
-	return nil
-}
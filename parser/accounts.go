@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+// accountFlags reports whether the account at index signs the transaction and whether it is
+// writable. Accounts are ordered as the runtime loads them: writable signers, read-only signers,
+// writable and read-only unsigned static keys, then writable and read-only lookup table keys.
+func accountFlags(header solana.MessageHeader, numStatic int, numWritableLoaded int, index int) (signer bool, writable bool) {
+	numSigners := int(header.NumRequiredSignatures)
+	switch {
+	case index < 0:
+		return false, false
+	case index < numSigners:
+		return true, index < numSigners-int(header.NumReadonlySignedAccounts)
+	case index < numStatic:
+		return false, index < numStatic-int(header.NumReadonlyUnsignedAccounts)
+	default:
+		return false, index-numStatic < numWritableLoaded
+	}
+}
+
+// messageAccountFlags reports the signer and writable status of an account of a message,
+// including lookup table accounts once the message is resolved
+func messageAccountFlags(message *solana.Message, index int) (signer bool, writable bool) {
+	if index >= len(message.AccountKeys) {
+		return false, false
+	}
+	numStatic := len(message.AccountKeys)
+	if message.IsResolved() {
+		numStatic -= message.NumLookups()
+	}
+	return accountFlags(message.Header, numStatic, message.NumWritableLookups(), index)
+}
+
+// instructionSigner returns the first account of an instruction that signed the transaction. The fee
+// payer is returned for instructions without a signer, e.g. CPIs signed by a program.
+func instructionSigner(instruction solana.CompiledInstruction, message *solana.Message) solana.PublicKey {
+	for _, accountIndex := range instruction.Accounts {
+		if signer, _ := messageAccountFlags(message, int(accountIndex)); signer {
+			return message.AccountKeys[accountIndex]
+		}
+	}
+	if len(message.AccountKeys) == 0 {
+		return solana.PublicKey{}
+	}
+	return message.AccountKeys[0]
+}
+
+// geyserInstructionSigner returns the first account of a Geyser instruction that signed the
+// transaction. Like instructionSigner it falls back to the fee payer, the first of the transaction's
+// account keys, for instructions without a signer or without signer flags.
+func geyserInstructionSigner(instruction GeyserInstruction, accountKeys []solana.PublicKey) solana.PublicKey {
+	for i, signer := range instruction.IsSigner {
+		if signer && i < len(instruction.Accounts) {
+			return instruction.Accounts[i]
+		}
+	}
+	if len(accountKeys) == 0 {
+		return solana.PublicKey{}
+	}
+	return accountKeys[0]
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestMessageAccountFlags(t *testing.T) {
+	keys := testKeys(5)
+	table := solana.NewWallet().PublicKey()
+	loaded := solana.PublicKeySlice{solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()}
+
+	// Two signers (the second read-only), two writable and one read-only unsigned key, then one
+	// writable and one read-only lookup table account
+	message := solana.Message{
+		Header:      solana.MessageHeader{NumRequiredSignatures: 2, NumReadonlySignedAccounts: 1, NumReadonlyUnsignedAccounts: 1},
+		AccountKeys: keys,
+		AddressTableLookups: solana.MessageAddressTableLookupSlice{
+			{AccountKey: table, WritableIndexes: []uint8{0}, ReadonlyIndexes: []uint8{1}},
+		},
+	}
+	message.SetVersion(solana.MessageVersionV0)
+	if err := message.SetAddressTables(map[solana.PublicKey]solana.PublicKeySlice{table: loaded}); err != nil {
+		t.Fatalf("Failed to set address tables: %v", err)
+	}
+	if err := message.ResolveLookups(); err != nil {
+		t.Fatalf("Failed to resolve lookups: %v", err)
+	}
+
+	expected := []struct{ signer, writable bool }{
+		{true, true}, {true, false}, {false, true}, {false, true}, {false, false}, {false, true}, {false, false},
+	}
+	for i, want := range expected {
+		signer, writable := messageAccountFlags(&message, i)
+		if signer != want.signer || writable != want.writable {
+			t.Errorf("Account %d: expected signer %v writable %v, got %v %v", i, want.signer, want.writable, signer, writable)
+		}
+	}
+}
+
+func TestRelayedTradeSigner(t *testing.T) {
+	relayer := solana.NewWallet().PublicKey()
+	user := solana.NewWallet().PublicKey()
+	keys := testKeys(16, relayer, user, RaydiumLaunchpadV1ProgramID)
+
+	data := instructionData(LaunchpadInstructions, LaunchpadSellExactIn, 1000, 1, 0)
+	accounts := append([]uint16{1}, accountRange(3, 16)...)
+	instruction := solana.CompiledInstruction{ProgramIDIndex: 2, Accounts: accounts, Data: data}
+
+	message := &solana.Message{
+		Header:       solana.MessageHeader{NumRequiredSignatures: 2, NumReadonlyUnsignedAccounts: 1},
+		AccountKeys:  keys,
+		Instructions: []solana.CompiledInstruction{instruction},
+	}
+	if signer := instructionSigner(instruction, message); !signer.Equals(user) {
+		t.Errorf("Expected instruction signer %s, got %s", user, signer)
+	}
+
+	result := &Transaction{}
+	defaultParser().parseMessageInstructions(message, nil, result)
+	if !result.FeePayer.Equals(relayer) {
+		t.Errorf("Expected fee payer %s, got %s", relayer, result.FeePayer)
+	}
+	if len(result.Trade) != 1 || !result.Trade[0].Trader.Equals(user) {
+		t.Errorf("Expected trade by %s, got %+v", user, result.Trade)
+	}
+}
+
+func TestGeyserInstructionSignerFallsBackToFeePayer(t *testing.T) {
+	feePayer := solana.NewWallet().PublicKey()
+	accounts := testKeys(2)
+	accountKeys := append(solana.PublicKeySlice{feePayer}, accounts...)
+
+	signed := GeyserInstruction{Accounts: accounts, IsSigner: []bool{false, true}}
+	if signer := geyserInstructionSigner(signed, accountKeys); !signer.Equals(accounts[1]) {
+		t.Errorf("Expected the signing account %s, got %s", accounts[1], signer)
+	}
+
+	// A CPI signed by a program, and an instruction without signer flags
+	for _, instruction := range []GeyserInstruction{
+		{Accounts: accounts, IsSigner: []bool{false, false}},
+		{Accounts: accounts},
+	} {
+		if signer := geyserInstructionSigner(instruction, accountKeys); !signer.Equals(feePayer) {
+			t.Errorf("Expected the fee payer %s, got %s", feePayer, signer)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Raydium AMM v4 instruction opcodes (the first byte of the instruction data)
+const (
+	AMM_V4_INITIALIZE            = 0
+	AMM_V4_INITIALIZE2           = 1
+	AMM_V4_MONITOR_STEP          = 2
+	AMM_V4_DEPOSIT               = 3
+	AMM_V4_WITHDRAW              = 4
+	AMM_V4_MIGRATE_TO_OPEN_BOOK  = 5
+	AMM_V4_SET_PARAMS            = 6
+	AMM_V4_WITHDRAW_PNL          = 7
+	AMM_V4_WITHDRAW_SRM          = 8
+	AMM_V4_SWAP_BASE_IN          = 9
+	AMM_V4_PRE_INITIALIZE        = 10
+	AMM_V4_SWAP_BASE_OUT         = 11
+	AMM_V4_SIMULATE_INFO         = 12
+	AMM_V4_ADMIN_CANCEL_ORDERS   = 13
+	AMM_V4_CREATE_CONFIG_ACCOUNT = 14
+	AMM_V4_UPDATE_CONFIG_ACCOUNT = 15
+	AMM_V4_SWAP_BASE_IN_V2       = 16
+	AMM_V4_SWAP_BASE_OUT_V2      = 17
+)
+
+var ammV4InstructionNames = map[byte]string{
+	AMM_V4_INITIALIZE:            "initialize",
+	AMM_V4_INITIALIZE2:           "initialize2",
+	AMM_V4_MONITOR_STEP:          "monitor_step",
+	AMM_V4_DEPOSIT:               "deposit",
+	AMM_V4_WITHDRAW:              "withdraw",
+	AMM_V4_MIGRATE_TO_OPEN_BOOK:  "migrate_to_open_book",
+	AMM_V4_SET_PARAMS:            "set_params",
+	AMM_V4_WITHDRAW_PNL:          "withdraw_pnl",
+	AMM_V4_WITHDRAW_SRM:          "withdraw_srm",
+	AMM_V4_SWAP_BASE_IN:          "swap_base_in",
+	AMM_V4_PRE_INITIALIZE:        "pre_initialize",
+	AMM_V4_SWAP_BASE_OUT:         "swap_base_out",
+	AMM_V4_SIMULATE_INFO:         "simulate_info",
+	AMM_V4_ADMIN_CANCEL_ORDERS:   "admin_cancel_orders",
+	AMM_V4_CREATE_CONFIG_ACCOUNT: "create_config_account",
+	AMM_V4_UPDATE_CONFIG_ACCOUNT: "update_config_account",
+	AMM_V4_SWAP_BASE_IN_V2:       "swap_base_in_v2",
+	AMM_V4_SWAP_BASE_OUT_V2:      "swap_base_out_v2",
+}
+
+// AMM v4 account layouts. Swaps and withdrawals exist with and without amm_target_orders, which the
+// program stopped requiring, and withdrawals also without pool_withdraw_queue and pool_temp_lp; the
+// variant is picked by account count. Deposits always pass all 14 accounts.
+var (
+	ammV4SwapLayout = AccountLayout{
+		"token_program", "amm", "amm_authority", "amm_open_orders", "amm_target_orders",
+		"pool_coin_token_account", "pool_pc_token_account", "serum_program", "serum_market",
+		"serum_bids", "serum_asks", "serum_event_queue", "serum_coin_vault", "serum_pc_vault",
+		"serum_vault_signer", "user_source_token_account", "user_destination_token_account", "user_source_owner",
+	}
+	ammV4SwapV2Layout = AccountLayout{
+		"token_program", "amm", "amm_authority", "pool_coin_token_account", "pool_pc_token_account",
+		"user_source_token_account", "user_destination_token_account", "user_source_owner",
+	}
+	ammV4Initialize2Layout = AccountLayout{
+		"token_program", "associated_token_program", "system_program", "rent", "amm", "amm_authority",
+		"amm_open_orders", "lp_mint", "coin_mint", "pc_mint", "pool_coin_token_account",
+		"pool_pc_token_account", "pool_withdraw_queue", "amm_target_orders", "pool_temp_lp",
+		"serum_program", "serum_market", "user_wallet", "user_token_coin", "user_token_pc", "user_lp_token_account",
+	}
+	ammV4DepositLayout = AccountLayout{
+		"token_program", "amm", "amm_authority", "amm_open_orders", "amm_target_orders", "lp_mint",
+		"pool_coin_token_account", "pool_pc_token_account", "serum_market", "user_coin_token_account",
+		"user_pc_token_account", "user_lp_token_account", "user_owner", "serum_event_queue",
+	}
+	ammV4WithdrawLayout = AccountLayout{
+		"token_program", "amm", "amm_authority", "amm_open_orders", "amm_target_orders", "lp_mint",
+		"pool_coin_token_account", "pool_pc_token_account", "pool_withdraw_queue", "pool_temp_lp",
+		"serum_program", "serum_market", "serum_coin_vault", "serum_pc_vault", "serum_vault_signer",
+		"user_lp_token_account", "user_coin_token_account", "user_pc_token_account", "user_owner",
+		"serum_event_queue", "serum_bids", "serum_asks",
+	}
+)
+
+// ammV4Layout returns the account layout of an AMM v4 instruction for the number of accounts it was given
+func ammV4Layout(opcode byte, numAccounts int) (AccountLayout, bool) {
+	var layout AccountLayout
+	switch opcode {
+	case AMM_V4_SWAP_BASE_IN, AMM_V4_SWAP_BASE_OUT:
+		layout = ammV4SwapLayout
+		if numAccounts == len(layout)-1 {
+			layout = layout.Without("amm_target_orders")
+		}
+	case AMM_V4_SWAP_BASE_IN_V2, AMM_V4_SWAP_BASE_OUT_V2:
+		layout = ammV4SwapV2Layout
+	case AMM_V4_INITIALIZE2:
+		layout = ammV4Initialize2Layout
+	case AMM_V4_DEPOSIT:
+		layout = ammV4DepositLayout
+	case AMM_V4_WITHDRAW:
+		layout = ammV4WithdrawLayout
+		switch numAccounts {
+		case len(layout) - 1:
+			layout = layout.Without("amm_target_orders")
+		case len(layout) - 2:
+			layout = layout.Without("pool_withdraw_queue", "pool_temp_lp")
+		}
+	default:
+		return nil, false
+	}
+
+	return layout, numAccounts >= len(layout)
+}
+
+// decodeAmmV4 is the ProgramDecoder of the AMM v4 and v5 programs
+func decodeAmmV4(ctx *InstructionContext, result *Transaction) error {
+	return ctx.parser().parseAmmV4Instruction(ctx.Data, ctx.Accounts, ctx.Index, ctx.Signer, ctx.tokens(), result)
+}
+
+// parseAmmV4Instruction decodes an AMM v4 instruction from its data and accounts. The mints of a swap
+// are those of the user's token accounts, taken from the token balances, with the pool vaults as fallback.
+func (p *Parser) parseAmmV4Instruction(data []byte, accounts []solana.PublicKey, index int, signer solana.PublicKey, tokens tokenAccounts, result *Transaction) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: instruction data is empty", ErrInstructionDataTooShort)
+	}
+
+	opcode := data[0]
+	name, ok := ammV4InstructionNames[opcode]
+	if !ok {
+		return fmt.Errorf("%w: AMM v4 opcode %d", ErrUnknownDiscriminator, opcode)
+	}
+
+	layout, ok := ammV4Layout(opcode, len(accounts))
+	if layout == nil {
+		p.logger.Printf("AMM v4 %s instruction at index %d", name, index)
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("%w for AMM v4 %s: %d of %d", ErrInsufficientAccounts, name, len(accounts), len(layout))
+	}
+	named := layout.Resolve(accounts)
+
+	args := func(count int) ([]uint64, error) {
+		if len(data) < 1+8*count {
+			return nil, fmt.Errorf("AMM v4 %s: %w: expected %d bytes, got %d", name, ErrInstructionDataTooShort, 1+8*count, len(data))
+		}
+		values := make([]uint64, count)
+		for i := range values {
+			values[i] = binary.LittleEndian.Uint64(data[1+8*i:])
+		}
+		return values, nil
+	}
+
+	switch opcode {
+	case AMM_V4_SWAP_BASE_IN, AMM_V4_SWAP_BASE_OUT, AMM_V4_SWAP_BASE_IN_V2, AMM_V4_SWAP_BASE_OUT_V2:
+		values, err := args(2)
+		if err != nil {
+			return err
+		}
+
+		trader := named["user_source_owner"]
+		if trader.IsZero() {
+			trader = signer
+		}
+		trade := TradeInfo{
+			InstructionIndex: index,
+			Pool:             named["amm"],
+			Trader:           trader,
+			TradeType:        "swap",
+			InstructionName:  name,
+		}
+		trade.TokenIn, trade.TokenOut = ammV4SwapMints(named, tokens)
+
+		// Exact-in swaps fix the amount in, exact-out swaps the amount out. The other side is what
+		// the pool vault of that mint paid out or took in.
+		if opcode == AMM_V4_SWAP_BASE_IN || opcode == AMM_V4_SWAP_BASE_IN_V2 {
+			trade.AmountIn, trade.MinAmountOut = values[0], values[1]
+			trade.AmountOut = tokens.vaultChange(named, trade.TokenOut, false)
+		} else {
+			trade.MaxAmountIn, trade.AmountOut = values[0], values[1]
+			trade.AmountIn = tokens.vaultChange(named, trade.TokenIn, true)
+		}
+		p.recordSwap(result, trade)
+
+	case AMM_V4_INITIALIZE2:
+		if len(data) < 26 {
+			return fmt.Errorf("AMM v4 %s: %w: expected 26 bytes, got %d", name, ErrInstructionDataTooShort, len(data))
+		}
+		// nonce u8, open_time u64, init_pc_amount u64, init_coin_amount u64
+		initPcAmount := binary.LittleEndian.Uint64(data[10:18])
+		initCoinAmount := binary.LittleEndian.Uint64(data[18:26])
+
+		tokenMint, amount := named["coin_mint"], initCoinAmount
+		if p.isBaseCurrency(tokenMint) {
+			tokenMint, amount = named["pc_mint"], initPcAmount
+		}
+		creator := named["user_wallet"]
+		if creator.IsZero() {
+			creator = signer
+		}
+		result.Create = append(result.Create, CreateInfo{
+			TokenMint:       tokenMint,
+			PoolAddress:     named["amm"],
+			Creator:         creator,
+			Amount:          amount,
+			InstructionName: name,
+		})
+
+	case AMM_V4_DEPOSIT:
+		// max_coin_amount, max_pc_amount, base_side; the LP minted is what the user's LP account received
+		if _, err := args(3); err != nil {
+			return err
+		}
+		coinVault, pcVault := named["pool_coin_token_account"], named["pool_pc_token_account"]
+		p.recordLiquidityAdd(result, LiquidityAdd{
+			InstructionIndex: index,
+			Pool:             named["amm"],
+			Provider:         ammV4Provider(named, signer),
+			LpMint:           named["lp_mint"],
+			LpMinted:         tokens.inflow(named["user_lp_token_account"]),
+			InstructionName:  name,
+		},
+			liquiditySide{Mint: tokens[coinVault].Mint, Amount: tokens.inflow(coinVault)},
+			liquiditySide{Mint: tokens[pcVault].Mint, Amount: tokens.inflow(pcVault)})
+
+	case AMM_V4_WITHDRAW:
+		// amount of LP tokens to burn
+		values, err := args(1)
+		if err != nil {
+			return err
+		}
+		coinVault, pcVault := named["pool_coin_token_account"], named["pool_pc_token_account"]
+		p.recordLiquidityRemove(result, LiquidityRemove{
+			InstructionIndex: index,
+			Pool:             named["amm"],
+			Provider:         ammV4Provider(named, signer),
+			LpMint:           named["lp_mint"],
+			LpBurned:         values[0],
+			InstructionName:  name,
+		},
+			liquiditySide{Mint: tokens[coinVault].Mint, Amount: tokens.outflow(coinVault)},
+			liquiditySide{Mint: tokens[pcVault].Mint, Amount: tokens.outflow(pcVault)})
+	}
+
+	return nil
+}
+
+// ammV4Provider returns the owner of the user accounts of a deposit or withdrawal
+func ammV4Provider(named map[string]solana.PublicKey, signer solana.PublicKey) solana.PublicKey {
+	if owner := named["user_owner"]; !owner.IsZero() {
+		return owner
+	}
+	return signer
+}
+
+// ammV4SwapMints finds the mints a swap sold and bought. The user's token accounts decide when their
+// mints are known; otherwise the pool's coin and pc vaults do, the one that grew being the mint sold.
+func ammV4SwapMints(named map[string]solana.PublicKey, tokens tokenAccounts) (tokenIn, tokenOut solana.PublicKey) {
+	source, sourceKnown := tokens[named["user_source_token_account"]]
+	destination, destinationKnown := tokens[named["user_destination_token_account"]]
+	coin := tokens[named["pool_coin_token_account"]]
+	pc := tokens[named["pool_pc_token_account"]]
+
+	other := func(mint solana.PublicKey) solana.PublicKey {
+		if mint.Equals(coin.Mint) {
+			return pc.Mint
+		}
+		return coin.Mint
+	}
+
+	switch {
+	case sourceKnown && destinationKnown:
+		return source.Mint, destination.Mint
+	case sourceKnown:
+		return source.Mint, other(source.Mint)
+	case destinationKnown:
+		return other(destination.Mint), destination.Mint
+	case coin.Post > coin.Pre:
+		return coin.Mint, pc.Mint
+	case pc.Post > pc.Pre:
+		return pc.Mint, coin.Mint
+	}
+	return solana.PublicKey{}, solana.PublicKey{}
+}
+
+// tokenAccount is the mint, owner and balances of a token account over a transaction
+type tokenAccount struct {
+	Mint  solana.PublicKey
+	Owner solana.PublicKey
+	Pre   uint64
+	Post  uint64
+}
+
+// tokenAccounts indexes the token balances of a transaction by token account
+type tokenAccounts map[solana.PublicKey]tokenAccount
+
+// newTokenAccounts indexes the token balances of the meta by the account keys they refer to
+func newTokenAccounts(accountKeys []solana.PublicKey, meta *TransactionMeta) tokenAccounts {
+	tokens := make(tokenAccounts)
+	if meta == nil {
+		return tokens
+	}
+	for _, balance := range meta.PreTokenBalances {
+		if balance.AccountIndex < len(accountKeys) {
+			account := tokens[accountKeys[balance.AccountIndex]]
+			account.Mint, account.Owner, account.Pre = balance.Mint, balance.Owner, balance.Amount
+			tokens[accountKeys[balance.AccountIndex]] = account
+		}
+	}
+	for _, balance := range meta.PostTokenBalances {
+		if balance.AccountIndex < len(accountKeys) {
+			account := tokens[accountKeys[balance.AccountIndex]]
+			account.Mint, account.Owner, account.Post = balance.Mint, balance.Owner, balance.Amount
+			tokens[accountKeys[balance.AccountIndex]] = account
+		}
+	}
+	return tokens
+}
+
+// vaultChange returns how much the pool vault holding mint took in (in true) or paid out, or 0
+// if neither of the pool's coin and pc vaults holds mint or it moved the other way
+func (t tokenAccounts) vaultChange(named map[string]solana.PublicKey, mint solana.PublicKey, in bool) uint64 {
+	if mint.IsZero() {
+		return 0
+	}
+	for _, name := range []string{"pool_coin_token_account", "pool_pc_token_account"} {
+		vault, ok := t[named[name]]
+		if !ok || !vault.Mint.Equals(mint) {
+			continue
+		}
+		if in {
+			return t.inflow(named[name])
+		}
+		return t.outflow(named[name])
+	}
+	return 0
+}
+
+// inflow returns how much the balance of a token account grew over the transaction, or 0
+func (t tokenAccounts) inflow(account solana.PublicKey) uint64 {
+	if balance, ok := t[account]; ok && balance.Post > balance.Pre {
+		return balance.Post - balance.Pre
+	}
+	return 0
+}
+
+// outflow returns how much the balance of a token account shrank over the transaction, or 0
+func (t tokenAccounts) outflow(account solana.PublicKey) uint64 {
+	if balance, ok := t[account]; ok && balance.Pre > balance.Post {
+		return balance.Pre - balance.Post
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestAmmV4LayoutVariants(t *testing.T) {
+	layout, ok := ammV4Layout(AMM_V4_SWAP_BASE_IN, 18)
+	if !ok || layout.Index("amm_target_orders") != 4 || layout.Index("user_source_owner") != 17 {
+		t.Errorf("Unexpected 18-account swap layout: %v", layout)
+	}
+	layout, ok = ammV4Layout(AMM_V4_SWAP_BASE_OUT, 17)
+	if !ok || layout.Index("amm_target_orders") != -1 || layout.Index("user_source_owner") != 16 {
+		t.Errorf("Unexpected 17-account swap layout: %v", layout)
+	}
+	if _, ok := ammV4Layout(AMM_V4_SWAP_BASE_IN, 10); ok {
+		t.Error("Expected a 10-account swap to be rejected")
+	}
+
+	layout, ok = ammV4Layout(AMM_V4_WITHDRAW, 22)
+	if !ok || layout.Index("pool_withdraw_queue") != 8 || layout.Index("serum_asks") != 21 {
+		t.Errorf("Unexpected 22-account withdraw layout: %v", layout)
+	}
+	layout, ok = ammV4Layout(AMM_V4_WITHDRAW, 21)
+	if !ok || layout.Index("amm_target_orders") != -1 || layout.Index("pool_withdraw_queue") != 7 || layout.Index("serum_asks") != 20 {
+		t.Errorf("Unexpected 21-account withdraw layout: %v", layout)
+	}
+	layout, ok = ammV4Layout(AMM_V4_WITHDRAW, 20)
+	if !ok || layout.Index("amm_target_orders") != 4 || layout.Index("pool_withdraw_queue") != -1 || layout.Index("pool_temp_lp") != -1 ||
+		layout.Index("serum_program") != 8 || layout.Index("user_owner") != 16 || layout.Index("serum_asks") != 19 {
+		t.Errorf("Unexpected 20-account withdraw layout: %v", layout)
+	}
+
+	layout, ok = ammV4Layout(AMM_V4_DEPOSIT, 14)
+	if !ok || layout.Index("amm_target_orders") != 4 || layout.Index("serum_event_queue") != 13 {
+		t.Errorf("Unexpected deposit layout: %v", layout)
+	}
+	if _, ok := ammV4Layout(AMM_V4_DEPOSIT, 13); ok {
+		t.Error("Expected a 13-account deposit to be rejected, as the program requires amm_target_orders")
+	}
+}
+
+func TestAmmV4SwapMintsFromTokenBalances(t *testing.T) {
+	keys := testKeys(18, solana.NewWallet().PublicKey(), RaydiumV4ProgramID)
+	owner, amm := keys[0], keys[3]
+	tokenMint := solana.NewWallet().PublicKey()
+
+	// 17-account swap_base_in without amm_target_orders: token_program, amm, amm_authority,
+	// amm_open_orders, the pool vaults (6, 7), eight Serum accounts, user source (16),
+	// user destination (17) and the owner
+	accounts := []uint16{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 0}
+	data := appendU64s([]byte{AMM_V4_SWAP_BASE_IN}, 500000000, 1000000)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+
+	// The wrapped SOL source account was opened and closed within the transaction, so only the
+	// destination and the vaults have token balances
+	meta := &TransactionMeta{
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 6, Mint: tokenMint, Owner: amm, Amount: 90000000000},
+			{AccountIndex: 7, Mint: solana.SolMint, Owner: amm, Amount: 80000000000},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 6, Mint: tokenMint, Owner: amm, Amount: 88750000000},
+			{AccountIndex: 7, Mint: solana.SolMint, Owner: amm, Amount: 80500000000},
+			{AccountIndex: 17, Mint: tokenMint, Owner: owner, Amount: 1250000000},
+		},
+	}
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.Trade) != 1 || len(result.SwapBuys) != 1 {
+		t.Fatalf("Expected 1 trade filed as a buy, got %d trades and %d swap buys", len(result.Trade), len(result.SwapBuys))
+	}
+	trade := result.Trade[0]
+	if !trade.TokenIn.Equals(solana.SolMint) || !trade.TokenOut.Equals(tokenMint) {
+		t.Errorf("Expected SOL in and the token out, got %s and %s", trade.TokenIn, trade.TokenOut)
+	}
+	if !trade.Pool.Equals(amm) || !trade.Trader.Equals(owner) || trade.InstructionName != "swap_base_in" {
+		t.Errorf("Unexpected trade: %+v", trade)
+	}
+	if trade.AmountIn != 500000000 || trade.AmountOut != 1250000000 {
+		t.Errorf("Expected 500000000 in and 1250000000 out, got %d and %d", trade.AmountIn, trade.AmountOut)
+	}
+}
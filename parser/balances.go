@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// BalanceChange is the net change of one owner's holdings of one mint over a transaction.
+// Native SOL and wrapped SOL are both reported under solana.SolMint.
+type BalanceChange struct {
+	Owner solana.PublicKey
+	Mint  solana.PublicKey
+	Delta int64
+}
+
+type balanceKey struct {
+	owner solana.PublicKey
+	mint  solana.PublicKey
+}
+
+// BalanceDeltas holds the per-owner, per-mint balance changes of a transaction, computed from
+// pre/post token balances and lamport balances
+type BalanceDeltas struct {
+	deltas map[balanceKey]int64
+}
+
+// ComputeBalanceDeltas builds the balance changes of a transaction from its full account list
+// (static keys followed by lookup table keys) and meta. The first account is the fee payer.
+//
+// SOL is measured in lamports: an owner's SOL change is the lamport change of their wallet plus
+// that of every token account they own, so rent moved into their own accounts and wrapped SOL
+// (whose token amount mirrors its lamports) are not counted twice. The fee is added back for the fee payer.
+func ComputeBalanceDeltas(accountKeys []solana.PublicKey, meta *TransactionMeta) *BalanceDeltas {
+	d := &BalanceDeltas{deltas: make(map[balanceKey]int64)}
+	if meta == nil {
+		return d
+	}
+
+	// Owner of every token account, from either side of the transaction
+	tokenAccountOwner := make(map[int]solana.PublicKey)
+	for _, balances := range [][]TokenBalance{meta.PreTokenBalances, meta.PostTokenBalances} {
+		for _, balance := range balances {
+			if !balance.Owner.IsZero() {
+				tokenAccountOwner[balance.AccountIndex] = balance.Owner
+			}
+		}
+	}
+
+	for _, balance := range meta.PreTokenBalances {
+		if !balance.Mint.Equals(solana.SolMint) {
+			d.add(balance.Owner, balance.Mint, -int64(balance.Amount))
+		}
+	}
+	for _, balance := range meta.PostTokenBalances {
+		if !balance.Mint.Equals(solana.SolMint) {
+			d.add(balance.Owner, balance.Mint, int64(balance.Amount))
+		}
+	}
+
+	for i := 0; i < len(accountKeys) && i < len(meta.PreBalances) && i < len(meta.PostBalances); i++ {
+		owner, isTokenAccount := tokenAccountOwner[i]
+		if !isTokenAccount {
+			owner = accountKeys[i]
+		}
+		d.add(owner, solana.SolMint, int64(meta.PostBalances[i])-int64(meta.PreBalances[i]))
+	}
+
+	if len(accountKeys) > 0 {
+		d.add(accountKeys[0], solana.SolMint, int64(meta.Fee))
+	}
+
+	return d
+}
+
+func (d *BalanceDeltas) add(owner, mint solana.PublicKey, amount int64) {
+	d.deltas[balanceKey{owner: owner, mint: mint}] += amount
+}
+
+// Delta returns the net change of an owner's holdings of a mint (solana.SolMint for SOL)
+func (d *BalanceDeltas) Delta(owner, mint solana.PublicKey) int64 {
+	return d.deltas[balanceKey{owner: owner, mint: mint}]
+}
+
+// Changes returns every non-zero balance change, ordered by owner then mint
+func (d *BalanceDeltas) Changes() []BalanceChange {
+	changes := make([]BalanceChange, 0, len(d.deltas))
+	for key, delta := range d.deltas {
+		if delta != 0 {
+			changes = append(changes, BalanceChange{Owner: key.owner, Mint: key.mint, Delta: delta})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if c := changes[i].Owner.String(); c != changes[j].Owner.String() {
+			return c < changes[j].Owner.String()
+		}
+		return changes[i].Mint.String() < changes[j].Mint.String()
+	})
+	return changes
+}
+
+// TradeAmounts returns what a trader paid of tokenIn and received of tokenOut, as far as the
+// balance changes show; ok is false unless both sides moved in the expected direction
+func (d *BalanceDeltas) TradeAmounts(trader, tokenIn, tokenOut solana.PublicKey) (paid uint64, received uint64, ok bool) {
+	in := d.Delta(trader, tokenIn)
+	out := d.Delta(trader, tokenOut)
+	if in >= 0 || out <= 0 {
+		return 0, 0, false
+	}
+	return uint64(-in), uint64(out), true
+}
+
+// applyBalanceDeltas fills the amounts of trades that no event reported exactly. Only a zero side is
+// filled: a side the instruction fixes is exact, while the balances also move with tips and fees.
+// Trades sharing a trader and token pair can't be told apart by balances and are left untouched.
+func applyBalanceDeltas(result *Transaction, deltas *BalanceDeltas) {
+	type pair struct{ trader, tokenIn, tokenOut solana.PublicKey }
+	counts := make(map[pair]int)
+	for _, trade := range result.Trade {
+		counts[pair{trade.Trader, trade.TokenIn, trade.TokenOut}]++
+	}
+
+	for i := range result.Trade {
+		trade := &result.Trade[i]
+		if trade.AmountIn != 0 && trade.AmountOut != 0 {
+			continue
+		}
+		if counts[pair{trade.Trader, trade.TokenIn, trade.TokenOut}] > 1 {
+			continue
+		}
+
+		paid, received, ok := deltas.TradeAmounts(trade.Trader, trade.TokenIn, trade.TokenOut)
+		if !ok {
+			continue
+		}
+
+		if trade.AmountIn == 0 {
+			trade.AmountIn = paid
+		}
+		if trade.AmountOut == 0 {
+			trade.AmountOut = received
+		}
+	}
+}
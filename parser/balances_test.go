@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestComputeBalanceDeltasSOL(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	tokenAccount := solana.NewWallet().PublicKey()
+	quoteVault := solana.NewWallet().PublicKey()
+	authority := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	keys := []solana.PublicKey{payer, tokenAccount, quoteVault}
+
+	const fee, rent = 5000, 2039280
+	meta := &TransactionMeta{
+		Fee:          fee,
+		PreBalances:  []uint64{10000000000, 0, 5000000000 + rent},
+		PostBalances: []uint64{10000000000 - fee - rent - 1000000000, rent, 6000000000 + rent},
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 2, Mint: solana.SolMint, Owner: authority, Amount: 5000000000},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 1, Mint: mint, Owner: payer, Amount: 7100},
+			{AccountIndex: 2, Mint: solana.SolMint, Owner: authority, Amount: 6000000000},
+		},
+	}
+
+	deltas := ComputeBalanceDeltas(keys, meta)
+	if got := deltas.Delta(payer, solana.SolMint); got != -1000000000 {
+		t.Errorf("Expected payer SOL delta -1000000000 without fee and rent, got %d", got)
+	}
+	if got := deltas.Delta(payer, mint); got != 7100 {
+		t.Errorf("Expected payer token delta 7100, got %d", got)
+	}
+	if got := deltas.Delta(authority, solana.SolMint); got != 1000000000 {
+		t.Errorf("Expected wrapped SOL counted once for the vault owner, got %d", got)
+	}
+	if changes := deltas.Changes(); len(changes) != 3 {
+		t.Errorf("Expected 3 balance changes, got %+v", changes)
+	}
+
+	paid, received, ok := deltas.TradeAmounts(payer, solana.SolMint, mint)
+	if !ok || paid != 1000000000 || received != 7100 {
+		t.Errorf("Expected paid 1000000000 and received 7100, got %d, %d (%v)", paid, received, ok)
+	}
+}
+
+func TestTradeAmountsFromTokenBalances(t *testing.T) {
+	encoded, keys := buildLaunchpadTradeTx(t, LaunchpadBuyExactIn, 200000000, 1)
+	payer := keys[0]
+	baseMint, quoteMint := keys[10], keys[11] // base_token_mint and quote_token_mint
+
+	meta := &TransactionMeta{
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 7, Mint: quoteMint, Owner: payer, Amount: 500000000},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 6, Mint: baseMint, Owner: payer, Amount: 7100000000000},
+			{AccountIndex: 7, Mint: quoteMint, Owner: payer, Amount: 300000000},
+		},
+	}
+
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.Trade) != 1 || result.Trade[0].AmountIn != 200000000 || result.Trade[0].AmountOut != 7100000000000 {
+		t.Errorf("Expected trade amounts from balance changes, got %+v", result.Trade)
+	}
+}
+
+func TestBalanceDeltasKeepExactAmountIn(t *testing.T) {
+	keys := testKeys(16, solana.NewWallet().PublicKey(), RaydiumLaunchpadV1ProgramID)
+	keys[11] = solana.SolMint // quote_token_mint
+	tipAccount := solana.MustPublicKeyFromBase58("96gYZGLnJYVFmbjzopPSU6QiEV5fGqZNyN9nmNhvrZU5")
+	keys = append(keys, tipAccount, SystemProgramID)
+	payer, baseMint := keys[0], keys[10]
+
+	tip := binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint32(nil, systemTransferInstruction), 1000000)
+	encoded := encodeTransaction(t, keys, 1,
+		solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: append([]uint16{0}, accountRange(2, 16)...),
+			Data: instructionData(LaunchpadInstructions, LaunchpadBuyExactIn, 200000000, 1, 0)},
+		solana.CompiledInstruction{ProgramIDIndex: 17, Accounts: []uint16{0, 16}, Data: tip})
+
+	// The payer's SOL went to the swap and the tip
+	meta := &TransactionMeta{
+		Fee:               5000,
+		PreBalances:       make([]uint64, len(keys)),
+		PostBalances:      make([]uint64, len(keys)),
+		PostTokenBalances: []TokenBalance{{AccountIndex: 6, Mint: baseMint, Owner: payer, Amount: 7100000000000}},
+	}
+	meta.PreBalances[0] = 1000000000
+	meta.PostBalances[0] = 1000000000 - 200000000 - 1000000 - 5000
+
+	result := mustParse(t, encoded, 0, meta)
+	if len(result.Trade) != 1 || result.Trade[0].AmountIn != 200000000 || result.Trade[0].AmountOut != 7100000000000 {
+		t.Errorf("Expected the exact amount in kept and the amount out filled, got %+v", result.Trade)
+	}
+	if result.JitoTip != 1000000 {
+		t.Errorf("Expected the tip detected, got %d", result.JitoTip)
+	}
+}
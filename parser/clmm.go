@@ -0,0 +1,271 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Raydium CLMM (concentrated liquidity) instruction names as declared in the program IDL
+const (
+	ClmmCreateAmmConfig            = "create_amm_config"
+	ClmmUpdateAmmConfig            = "update_amm_config"
+	ClmmCreatePool                 = "create_pool"
+	ClmmUpdatePoolStatus           = "update_pool_status"
+	ClmmOpenPosition               = "open_position"
+	ClmmOpenPositionV2             = "open_position_v2"
+	ClmmOpenPositionWithToken22Nft = "open_position_with_token22_nft"
+	ClmmClosePosition              = "close_position"
+	ClmmIncreaseLiquidity          = "increase_liquidity"
+	ClmmIncreaseLiquidityV2        = "increase_liquidity_v2"
+	ClmmDecreaseLiquidity          = "decrease_liquidity"
+	ClmmDecreaseLiquidityV2        = "decrease_liquidity_v2"
+	ClmmSwap                       = "swap"
+	ClmmSwapV2                     = "swap_v2"
+	ClmmSwapRouterBaseIn           = "swap_router_base_in"
+	ClmmInitializeReward           = "initialize_reward"
+	ClmmCollectRemainingRewards    = "collect_remaining_rewards"
+	ClmmUpdateRewardInfos          = "update_reward_infos"
+	ClmmSetRewardParams            = "set_reward_params"
+	ClmmCollectProtocolFee         = "collect_protocol_fee"
+	ClmmCollectFundFee             = "collect_fund_fee"
+	ClmmCreateOperationAccount     = "create_operation_account"
+	ClmmUpdateOperationAccount     = "update_operation_account"
+	ClmmTransferRewardOwner        = "transfer_reward_owner"
+)
+
+// ClmmInstructions holds the discriminators of the Raydium CLMM instructions
+var ClmmInstructions = NewDiscriminatorRegistry(RaydiumClmmProgramID,
+	ClmmCreateAmmConfig,
+	ClmmUpdateAmmConfig,
+	ClmmCreatePool,
+	ClmmUpdatePoolStatus,
+	ClmmOpenPosition,
+	ClmmOpenPositionV2,
+	ClmmOpenPositionWithToken22Nft,
+	ClmmClosePosition,
+	ClmmIncreaseLiquidity,
+	ClmmIncreaseLiquidityV2,
+	ClmmDecreaseLiquidity,
+	ClmmDecreaseLiquidityV2,
+	ClmmSwap,
+	ClmmSwapV2,
+	ClmmSwapRouterBaseIn,
+	ClmmInitializeReward,
+	ClmmCollectRemainingRewards,
+	ClmmUpdateRewardInfos,
+	ClmmSetRewardParams,
+	ClmmCollectProtocolFee,
+	ClmmCollectFundFee,
+	ClmmCreateOperationAccount,
+	ClmmUpdateOperationAccount,
+	ClmmTransferRewardOwner,
+)
+
+// CLMM account layouts, as declared in the program IDL. Swaps take further tick arrays as remaining accounts.
+var (
+	clmmSwapLayout = AccountLayout{
+		"payer", "amm_config", "pool_state", "input_token_account", "output_token_account",
+		"input_vault", "output_vault", "observation_state", "token_program", "tick_array",
+	}
+	clmmSwapV2Layout = AccountLayout{
+		"payer", "amm_config", "pool_state", "input_token_account", "output_token_account",
+		"input_vault", "output_vault", "observation_state", "token_program", "token_program_2022",
+		"memo_program", "input_vault_mint", "output_vault_mint",
+	}
+	clmmCreatePoolLayout = AccountLayout{
+		"pool_creator", "amm_config", "pool_state", "token_mint_0", "token_mint_1", "token_vault_0",
+		"token_vault_1", "observation_state", "tick_array_bitmap", "token_program_0", "token_program_1",
+		"system_program", "rent",
+	}
+	clmmOpenPositionLayout = AccountLayout{
+		"payer", "position_nft_owner", "position_nft_mint", "position_nft_account", "metadata_account",
+		"pool_state", "protocol_position", "tick_array_lower", "tick_array_upper", "personal_position",
+		"token_account_0", "token_account_1", "token_vault_0", "token_vault_1", "rent", "system_program",
+		"token_program", "associated_token_program", "metadata_program",
+	}
+	clmmOpenPositionV2Layout = append(append(AccountLayout{}, clmmOpenPositionLayout...),
+		"token_program_2022", "vault_0_mint", "vault_1_mint")
+	clmmOpenPositionToken22NftLayout = AccountLayout{
+		"payer", "position_nft_owner", "position_nft_mint", "position_nft_account", "pool_state",
+		"protocol_position", "tick_array_lower", "tick_array_upper", "personal_position",
+		"token_account_0", "token_account_1", "token_vault_0", "token_vault_1", "rent", "system_program",
+		"token_program", "associated_token_program", "token_program_2022", "vault_0_mint", "vault_1_mint",
+	}
+	clmmIncreaseLiquidityLayout = AccountLayout{
+		"nft_owner", "nft_account", "pool_state", "protocol_position", "personal_position",
+		"tick_array_lower", "tick_array_upper", "token_account_0", "token_account_1",
+		"token_vault_0", "token_vault_1", "token_program",
+	}
+	clmmIncreaseLiquidityV2Layout = append(append(AccountLayout{}, clmmIncreaseLiquidityLayout...),
+		"token_program_2022", "vault_0_mint", "vault_1_mint")
+	clmmDecreaseLiquidityLayout = AccountLayout{
+		"nft_owner", "nft_account", "personal_position", "pool_state", "protocol_position",
+		"token_vault_0", "token_vault_1", "tick_array_lower", "tick_array_upper",
+		"recipient_token_account_0", "recipient_token_account_1", "token_program",
+	}
+	clmmDecreaseLiquidityV2Layout = append(append(AccountLayout{}, clmmDecreaseLiquidityLayout...),
+		"token_program_2022", "memo_program", "vault_0_mint", "vault_1_mint")
+)
+
+// ClmmAccountLayouts maps CLMM instruction names to their account layouts
+var ClmmAccountLayouts = map[string]AccountLayout{
+	ClmmSwap:                       clmmSwapLayout,
+	ClmmSwapV2:                     clmmSwapV2Layout,
+	ClmmCreatePool:                 clmmCreatePoolLayout,
+	ClmmOpenPosition:               clmmOpenPositionLayout,
+	ClmmOpenPositionV2:             clmmOpenPositionV2Layout,
+	ClmmOpenPositionWithToken22Nft: clmmOpenPositionToken22NftLayout,
+	ClmmIncreaseLiquidity:          clmmIncreaseLiquidityLayout,
+	ClmmIncreaseLiquidityV2:        clmmIncreaseLiquidityV2Layout,
+	ClmmDecreaseLiquidity:          clmmDecreaseLiquidityLayout,
+	ClmmDecreaseLiquidityV2:        clmmDecreaseLiquidityV2Layout,
+}
+
+// decodeClmm is the ProgramDecoder of the CLMM program
+func decodeClmm(ctx *InstructionContext, result *Transaction) error {
+	return ctx.parser().parseClmmInstruction(ctx.ProgramID, ctx.Data, ctx.Accounts, ctx.Index, ctx.Signer, ctx.tokens(), result)
+}
+
+// parseClmmInstruction decodes a CLMM instruction through the program IDL. Swaps become trades,
+// create_pool a create and position changes liquidity adds and removes.
+func (p *Parser) parseClmmInstruction(programID solana.PublicKey, data []byte, accounts []solana.PublicKey, index int, signer solana.PublicKey, tokens tokenAccounts, result *Transaction) error {
+	if isAnchorEventCPI(data) {
+		return nil
+	}
+	decoded, err := p.decodeInstructionWithIDL(programID, data, accounts)
+	if err != nil {
+		return fmt.Errorf("CLMM: %w", err)
+	}
+	name, args := decoded.Name, decoded.Args
+
+	layout := ClmmAccountLayouts[name]
+	if layout == nil {
+		p.logger.Printf("CLMM %s instruction at index %d", name, index)
+		return nil
+	}
+	if len(accounts) < len(layout) {
+		return fmt.Errorf("%w for CLMM %s: %d of %d", ErrInsufficientAccounts, name, len(accounts), len(layout))
+	}
+	named := decoded.Accounts
+
+	switch name {
+	case ClmmSwap, ClmmSwapV2:
+		amount, threshold := args.Uint64("amount"), args.Uint64("other_amount_threshold")
+
+		trader := named["payer"]
+		if trader.IsZero() {
+			trader = signer
+		}
+		trade := TradeInfo{
+			InstructionIndex: index,
+			TokenIn:          named["input_vault_mint"],
+			TokenOut:         named["output_vault_mint"],
+			Pool:             named["pool_state"],
+			Trader:           trader,
+			TradeType:        "swap",
+			InstructionName:  name,
+		}
+		// swap doesn't pass the mints; the vaults' token balances have them
+		if trade.TokenIn.IsZero() {
+			trade.TokenIn = tokens[named["input_vault"]].Mint
+		}
+		if trade.TokenOut.IsZero() {
+			trade.TokenOut = tokens[named["output_vault"]].Mint
+		}
+
+		// is_base_input fixes the amount in and makes the threshold a minimum out, otherwise the amount
+		// out is fixed and the threshold is a maximum in; the other side is what the vault took in or paid out
+		if args.Bool("is_base_input") {
+			trade.AmountIn, trade.MinAmountOut = amount, threshold
+			trade.AmountOut = tokens.outflow(named["output_vault"])
+		} else {
+			trade.AmountOut, trade.MaxAmountIn = amount, threshold
+			trade.AmountIn = tokens.inflow(named["input_vault"])
+		}
+		p.recordSwap(result, trade)
+
+	case ClmmCreatePool:
+		// The token being listed is the side that isn't a base currency
+		tokenMint := named["token_mint_0"]
+		if p.isBaseCurrency(tokenMint) {
+			tokenMint = named["token_mint_1"]
+		}
+		creator := named["pool_creator"]
+		if creator.IsZero() {
+			creator = signer
+		}
+		result.Create = append(result.Create, CreateInfo{
+			TokenMint:       tokenMint,
+			PoolAddress:     named["pool_state"],
+			Creator:         creator,
+			InstructionName: name,
+		})
+		p.logger.Printf("CLMM %s at index %d: pool %s, sqrt price x64 %v", name, index, named["pool_state"], args["sqrt_price_x64"])
+
+	case ClmmOpenPosition, ClmmOpenPositionV2, ClmmOpenPositionWithToken22Nft:
+		provider := named["payer"]
+		if provider.IsZero() {
+			provider = signer
+		}
+		token0, token1 := clmmVaultSides(named, tokens, true)
+		p.recordLiquidityAdd(result, LiquidityAdd{
+			InstructionIndex: index,
+			Pool:             named["pool_state"],
+			Provider:         provider,
+			PositionMint:     named["position_nft_mint"],
+			TickLower:        args.Int32("tick_lower_index"),
+			TickUpper:        args.Int32("tick_upper_index"),
+			InstructionName:  name,
+		}, token0, token1)
+
+	case ClmmIncreaseLiquidity, ClmmIncreaseLiquidityV2, ClmmDecreaseLiquidity, ClmmDecreaseLiquidityV2:
+		provider := named["nft_owner"]
+		if provider.IsZero() {
+			provider = signer
+		}
+		// The position NFT is the mint of the owner's NFT account, known from the token balances
+		positionMint := tokens[named["nft_account"]].Mint
+
+		if name == ClmmIncreaseLiquidity || name == ClmmIncreaseLiquidityV2 {
+			token0, token1 := clmmVaultSides(named, tokens, true)
+			p.recordLiquidityAdd(result, LiquidityAdd{
+				InstructionIndex: index,
+				Pool:             named["pool_state"],
+				Provider:         provider,
+				PositionMint:     positionMint,
+				InstructionName:  name,
+			}, token0, token1)
+		} else {
+			token0, token1 := clmmVaultSides(named, tokens, false)
+			p.recordLiquidityRemove(result, LiquidityRemove{
+				InstructionIndex: index,
+				Pool:             named["pool_state"],
+				Provider:         provider,
+				PositionMint:     positionMint,
+				InstructionName:  name,
+			}, token0, token1)
+		}
+	}
+
+	return nil
+}
+
+// clmmVaultSides returns the two tokens of a pool with what their vaults took in, or paid out when
+// in is false. Mints the instruction doesn't pass come from the vaults' token balances.
+func clmmVaultSides(named map[string]solana.PublicKey, tokens tokenAccounts, in bool) (liquiditySide, liquiditySide) {
+	var sides [2]liquiditySide
+	for i, suffix := range []string{"0", "1"} {
+		vault := named["token_vault_"+suffix]
+		mint := named["vault_"+suffix+"_mint"]
+		if mint.IsZero() {
+			mint = tokens[vault].Mint
+		}
+		amount := tokens.outflow(vault)
+		if in {
+			amount = tokens.inflow(vault)
+		}
+		sides[i] = liquiditySide{Mint: mint, Amount: amount}
+	}
+	return sides[0], sides[1]
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestClmmSwapDiscriminators(t *testing.T) {
+	expected := map[string]string{
+		ClmmSwap:   "f8c69e91e17587c8",
+		ClmmSwapV2: "2b04ed0b1ac91e62",
+	}
+	for name, want := range expected {
+		disc, _ := ClmmInstructions.Discriminator(name)
+		if got := hex.EncodeToString(disc[:]); got != want {
+			t.Errorf("%s: expected discriminator %s, got %s", name, want, got)
+		}
+	}
+}
+
+func TestClmmSwapBaseOutput(t *testing.T) {
+	keys := testKeys(12, solana.NewWallet().PublicKey(), RaydiumClmmProgramID)
+	payer, pool := keys[0], keys[4]
+	tokenMint := solana.NewWallet().PublicKey()
+
+	// amount 2 SOL out, at most 900000000 tokens in, no price limit, is_base_input false
+	data := append(instructionData(ClmmInstructions, ClmmSwap, 2000000000, 900000000), make([]byte, 17)...)
+
+	// Accounts in layout order: payer, then keys 3 to 11 (input vault 7, output vault 8)
+	accounts := append([]uint16{0}, accountRange(3, 12)...)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+
+	meta := &TransactionMeta{
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 7, Mint: tokenMint, Owner: pool, Amount: 50000000000},
+			{AccountIndex: 8, Mint: solana.SolMint, Owner: pool, Amount: 90000000000},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 7, Mint: tokenMint, Owner: pool, Amount: 50850000000},
+			{AccountIndex: 8, Mint: solana.SolMint, Owner: pool, Amount: 88000000000},
+		},
+	}
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.Trade) != 1 || len(result.SwapSells) != 1 {
+		t.Fatalf("Expected 1 trade filed as a sell, got %d trades and %d swap sells", len(result.Trade), len(result.SwapSells))
+	}
+	trade := result.Trade[0]
+	if !trade.Pool.Equals(pool) || !trade.TokenIn.Equals(tokenMint) || !trade.TokenOut.Equals(solana.SolMint) || !trade.Trader.Equals(payer) {
+		t.Errorf("Unexpected accounts: %+v", trade)
+	}
+	if trade.InstructionName != ClmmSwap || trade.AmountIn != 850000000 || trade.AmountOut != 2000000000 {
+		t.Errorf("Unexpected trade: %+v", trade)
+	}
+	// other_amount_threshold is the maximum in of an exact-out swap, not a minimum out
+	if trade.MaxAmountIn != 900000000 || trade.MinAmountOut != 0 {
+		t.Errorf("Expected a maximum in of 900000000 and no minimum out, got %d and %d", trade.MaxAmountIn, trade.MinAmountOut)
+	}
+	// 50000000 under the maximum in
+	if sell := result.SwapSells[0]; sell.MaxAmountIn != 900000000 || sell.MinAmountOut != 0 || sell.Slippage < 0.0555 || sell.Slippage > 0.0556 {
+		t.Errorf("Unexpected swap sell: %+v", sell)
+	}
+}
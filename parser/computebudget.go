@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+var ComputeBudgetProgramID = solana.MustPublicKeyFromBase58("ComputeBudget111111111111111111111111111111")
+
+// ComputeBudget instruction discriminators
+const (
+	computeBudgetRequestUnitsDeprecated         = 0
+	computeBudgetRequestHeapFrame               = 1
+	computeBudgetSetComputeUnitLimit            = 2
+	computeBudgetSetComputeUnitPrice            = 3
+	computeBudgetSetLoadedAccountsDataSizeLimit = 4
+)
+
+// Runtime defaults used when a transaction doesn't set its compute unit limit
+const (
+	defaultInstructionComputeUnitLimit = 200000
+	maxComputeUnitLimit                = 1400000
+)
+
+// ComputeBudget is what a transaction requested and paid to be executed
+type ComputeBudget struct {
+	ComputeUnitLimit     uint32 // SetComputeUnitLimit; 0 if not set
+	ComputeUnitPrice     uint64 // SetComputeUnitPrice, in micro-lamports per compute unit; 0 if not set
+	ComputeUnitsConsumed uint64 // From meta; 0 if not reported
+	Fee                  uint64 // meta.fee in lamports, base and priority fee together
+	PriorityFee          uint64 // Lamports paid on top of the base fee: price times the requested (or default) limit
+}
+
+// decodeComputeBudget is the ProgramDecoder of the ComputeBudget program
+func decodeComputeBudget(ctx *InstructionContext, result *Transaction) error {
+	return ctx.parser().parseComputeBudgetInstruction(ctx.Data, ctx.Index, result)
+}
+
+// parseComputeBudgetInstruction records the compute unit limit and price set by a ComputeBudget instruction
+func (p *Parser) parseComputeBudgetInstruction(data []byte, index int, result *Transaction) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: compute budget instruction data is empty", ErrInstructionDataTooShort)
+	}
+
+	switch data[0] {
+	case computeBudgetSetComputeUnitLimit:
+		if len(data) < 5 {
+			return fmt.Errorf("%w: SetComputeUnitLimit has %d bytes", ErrInstructionDataTooShort, len(data))
+		}
+		result.ComputeBudget.ComputeUnitLimit = binary.LittleEndian.Uint32(data[1:5])
+		p.logger.Printf("Compute unit limit at index %d: %d", index, result.ComputeBudget.ComputeUnitLimit)
+	case computeBudgetSetComputeUnitPrice:
+		if len(data) < 9 {
+			return fmt.Errorf("%w: SetComputeUnitPrice has %d bytes", ErrInstructionDataTooShort, len(data))
+		}
+		result.ComputeBudget.ComputeUnitPrice = binary.LittleEndian.Uint64(data[1:9])
+		p.logger.Printf("Compute unit price at index %d: %d micro-lamports", index, result.ComputeBudget.ComputeUnitPrice)
+	case computeBudgetRequestUnitsDeprecated, computeBudgetRequestHeapFrame, computeBudgetSetLoadedAccountsDataSizeLimit:
+		// Don't affect what the transaction pays for priority
+	default:
+		return fmt.Errorf("%w: compute budget instruction %d", ErrUnknownDiscriminator, data[0])
+	}
+	return nil
+}
+
+// applyComputeBudget fills in the fee and compute units reported by the meta and derives the priority
+// fee. programIDs are the programs of the top-level instructions.
+func applyComputeBudget(result *Transaction, meta *TransactionMeta, programIDs []solana.PublicKey) {
+	budget := &result.ComputeBudget
+	if meta != nil {
+		budget.Fee = meta.Fee
+		budget.ComputeUnitsConsumed = meta.ComputeUnitsConsumed
+	}
+
+	limit := uint64(budget.ComputeUnitLimit)
+	if limit == 0 {
+		for _, programID := range programIDs {
+			if !programID.Equals(ComputeBudgetProgramID) {
+				limit += defaultInstructionComputeUnitLimit
+			}
+		}
+	}
+	if limit > maxComputeUnitLimit {
+		limit = maxComputeUnitLimit
+	}
+
+	// The runtime rounds the priority fee up to the next lamport
+	microLamports := budget.ComputeUnitPrice * limit
+	budget.PriorityFee = microLamports / 1000000
+	if microLamports%1000000 != 0 {
+		budget.PriorityFee++
+	}
+}
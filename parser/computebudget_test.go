@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestComputeBudgetAndPriorityFee(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	keys := solana.PublicKeySlice{payer, ComputeBudgetProgramID}
+
+	limit := binary.LittleEndian.AppendUint32([]byte{computeBudgetSetComputeUnitLimit}, 150000)
+	price := binary.LittleEndian.AppendUint64([]byte{computeBudgetSetComputeUnitPrice}, 2500000)
+
+	encoded := encodeTransaction(t, keys, 1,
+		solana.CompiledInstruction{ProgramIDIndex: 1, Data: limit},
+		solana.CompiledInstruction{ProgramIDIndex: 1, Data: price})
+
+	result := mustParse(t, encoded, 0, &TransactionMeta{Fee: 380000, ComputeUnitsConsumed: 98765})
+
+	budget := result.ComputeBudget
+	if budget.ComputeUnitLimit != 150000 || budget.ComputeUnitPrice != 2500000 {
+		t.Errorf("Unexpected limit %d and price %d", budget.ComputeUnitLimit, budget.ComputeUnitPrice)
+	}
+	if budget.Fee != 380000 || budget.ComputeUnitsConsumed != 98765 {
+		t.Errorf("Unexpected fee %d and consumed units %d", budget.Fee, budget.ComputeUnitsConsumed)
+	}
+	// 2.5 lamports per compute unit for 150k units
+	if budget.PriorityFee != 375000 {
+		t.Errorf("Expected priority fee 375000, got %d", budget.PriorityFee)
+	}
+}
+
+func TestPriorityFeeDefaultLimit(t *testing.T) {
+	result := &Transaction{ComputeBudget: ComputeBudget{ComputeUnitPrice: 1000001}}
+	applyComputeBudget(result, nil, []solana.PublicKey{ComputeBudgetProgramID, RaydiumLaunchpadV1ProgramID})
+
+	// One non-ComputeBudget instruction gets the 200k default, and the fee rounds up
+	if result.ComputeBudget.PriorityFee != 200001 {
+		t.Errorf("Expected priority fee 200001, got %d", result.ComputeBudget.PriorityFee)
+	}
+}
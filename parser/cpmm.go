@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Raydium CPMM (CP Swap) instruction names as declared in the program IDL
+const (
+	CpmmInitialize         = "initialize"
+	CpmmDeposit            = "deposit"
+	CpmmWithdraw           = "withdraw"
+	CpmmSwapBaseInput      = "swap_base_input"
+	CpmmSwapBaseOutput     = "swap_base_output"
+	CpmmCreateAmmConfig    = "create_amm_config"
+	CpmmUpdateAmmConfig    = "update_amm_config"
+	CpmmUpdatePoolStatus   = "update_pool_status"
+	CpmmCollectFundFee     = "collect_fund_fee"
+	CpmmCollectProtocolFee = "collect_protocol_fee"
+)
+
+// CpmmInstructions holds the discriminators of the Raydium CPMM instructions
+var CpmmInstructions = NewDiscriminatorRegistry(RaydiumCpSwapProgramID,
+	CpmmInitialize,
+	CpmmDeposit,
+	CpmmWithdraw,
+	CpmmSwapBaseInput,
+	CpmmSwapBaseOutput,
+	CpmmCreateAmmConfig,
+	CpmmUpdateAmmConfig,
+	CpmmUpdatePoolStatus,
+	CpmmCollectFundFee,
+	CpmmCollectProtocolFee,
+)
+
+// CPMM account layouts, as declared in the program IDL
+var (
+	cpmmSwapLayout = AccountLayout{
+		"payer", "authority", "amm_config", "pool_state", "input_token_account", "output_token_account",
+		"input_vault", "output_vault", "input_token_program", "output_token_program",
+		"input_token_mint", "output_token_mint", "observation_state",
+	}
+	cpmmInitializeLayout = AccountLayout{
+		"creator", "amm_config", "authority", "pool_state", "token_0_mint", "token_1_mint", "lp_mint",
+		"creator_token_0", "creator_token_1", "creator_lp_token", "token_0_vault", "token_1_vault",
+		"create_pool_fee", "observation_state", "token_program", "token_0_program", "token_1_program",
+		"associated_token_program", "system_program", "rent",
+	}
+	cpmmDepositLayout = AccountLayout{
+		"owner", "authority", "pool_state", "owner_lp_token", "token_0_account", "token_1_account",
+		"token_0_vault", "token_1_vault", "token_program", "token_program_2022", "vault_0_mint",
+		"vault_1_mint", "lp_mint",
+	}
+	cpmmWithdrawLayout = append(append(AccountLayout{}, cpmmDepositLayout...), "memo_program")
+)
+
+// CpmmAccountLayouts maps CPMM instruction names to their account layouts
+var CpmmAccountLayouts = map[string]AccountLayout{
+	CpmmSwapBaseInput:  cpmmSwapLayout,
+	CpmmSwapBaseOutput: cpmmSwapLayout,
+	CpmmInitialize:     cpmmInitializeLayout,
+	CpmmDeposit:        cpmmDepositLayout,
+	CpmmWithdraw:       cpmmWithdrawLayout,
+}
+
+// decodeCpmm is the ProgramDecoder of the CP Swap program
+func decodeCpmm(ctx *InstructionContext, result *Transaction) error {
+	return ctx.parser().parseCpmmInstruction(ctx.ProgramID, ctx.Data, ctx.Accounts, ctx.Index, ctx.Signer, ctx.tokens(), result)
+}
+
+// parseCpmmInstruction decodes a CPMM instruction through the program IDL. Swaps become trades,
+// initialize a create and deposit/withdraw liquidity events.
+func (p *Parser) parseCpmmInstruction(programID solana.PublicKey, data []byte, accounts []solana.PublicKey, index int, signer solana.PublicKey, tokens tokenAccounts, result *Transaction) error {
+	if isAnchorEventCPI(data) {
+		return nil
+	}
+	decoded, err := p.decodeInstructionWithIDL(programID, data, accounts)
+	if err != nil {
+		return fmt.Errorf("CP Swap: %w", err)
+	}
+	name, args := decoded.Name, decoded.Args
+
+	layout := CpmmAccountLayouts[name]
+	if layout == nil {
+		p.logger.Printf("CP Swap %s instruction at index %d", name, index)
+		return nil
+	}
+	if len(accounts) < len(layout) {
+		return fmt.Errorf("%w for CP Swap %s: %d of %d", ErrInsufficientAccounts, name, len(accounts), len(layout))
+	}
+	named := decoded.Accounts
+
+	switch name {
+	case CpmmSwapBaseInput, CpmmSwapBaseOutput:
+		trader := named["payer"]
+		if trader.IsZero() {
+			trader = signer
+		}
+		trade := TradeInfo{
+			InstructionIndex: index,
+			TokenIn:          named["input_token_mint"],
+			TokenOut:         named["output_token_mint"],
+			Pool:             named["pool_state"],
+			Trader:           trader,
+			TradeType:        "swap",
+			InstructionName:  name,
+		}
+
+		// swap_base_input fixes the amount in, swap_base_output the amount out; the other side is
+		// what the output vault paid out or the input vault took in
+		if name == CpmmSwapBaseInput {
+			trade.AmountIn, trade.MinAmountOut = args.Uint64("amount_in"), args.Uint64("minimum_amount_out")
+			trade.AmountOut = tokens.outflow(named["output_vault"])
+		} else {
+			trade.MaxAmountIn, trade.AmountOut = args.Uint64("max_amount_in"), args.Uint64("amount_out")
+			trade.AmountIn = tokens.inflow(named["input_vault"])
+		}
+		p.recordSwap(result, trade)
+
+	case CpmmInitialize:
+		// The token being listed is the side that isn't a base currency
+		tokenMint, amount := named["token_0_mint"], args.Uint64("init_amount_0")
+		if p.isBaseCurrency(tokenMint) {
+			tokenMint, amount = named["token_1_mint"], args.Uint64("init_amount_1")
+		}
+		creator := named["creator"]
+		if creator.IsZero() {
+			creator = signer
+		}
+		result.Create = append(result.Create, CreateInfo{
+			TokenMint:       tokenMint,
+			PoolAddress:     named["pool_state"],
+			Creator:         creator,
+			Amount:          amount,
+			InstructionName: name,
+		})
+
+	case CpmmDeposit, CpmmWithdraw:
+		provider := named["owner"]
+		if provider.IsZero() {
+			provider = signer
+		}
+		vault0, vault1 := named["token_0_vault"], named["token_1_vault"]
+		if name == CpmmDeposit {
+			p.recordLiquidityAdd(result, LiquidityAdd{
+				InstructionIndex: index,
+				Pool:             named["pool_state"],
+				Provider:         provider,
+				LpMint:           named["lp_mint"],
+				LpMinted:         args.Uint64("lp_token_amount"),
+				InstructionName:  name,
+			},
+				liquiditySide{Mint: named["vault_0_mint"], Amount: tokens.inflow(vault0)},
+				liquiditySide{Mint: named["vault_1_mint"], Amount: tokens.inflow(vault1)})
+		} else {
+			p.recordLiquidityRemove(result, LiquidityRemove{
+				InstructionIndex: index,
+				Pool:             named["pool_state"],
+				Provider:         provider,
+				LpMint:           named["lp_mint"],
+				LpBurned:         args.Uint64("lp_token_amount"),
+				InstructionName:  name,
+			},
+				liquiditySide{Mint: named["vault_0_mint"], Amount: tokens.outflow(vault0)},
+				liquiditySide{Mint: named["vault_1_mint"], Amount: tokens.outflow(vault1)})
+		}
+	}
+
+	return nil
+}
+
+// recordSwap appends a swap trade; whether it is a buy or a sell is decided once the transaction
+// is parsed.
+func (p *Parser) recordSwap(result *Transaction, trade TradeInfo) {
+	result.Trade = append(result.Trade, trade)
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestCpmmSwapDiscriminators(t *testing.T) {
+	expected := map[string]string{
+		CpmmSwapBaseInput:  "8fbe5adac41e33de",
+		CpmmSwapBaseOutput: "37d96256a34ab4ad",
+	}
+	for name, want := range expected {
+		disc, _ := CpmmInstructions.Discriminator(name)
+		if got := hex.EncodeToString(disc[:]); got != want {
+			t.Errorf("%s: expected discriminator %s, got %s", name, want, got)
+		}
+	}
+}
+
+func TestCpmmSwapBaseInput(t *testing.T) {
+	keys := testKeys(15, solana.NewWallet().PublicKey(), RaydiumCpSwapProgramID)
+	keys[12] = solana.SolMint // input_token_mint
+	payer, pool, outputMint := keys[0], keys[5], keys[13]
+
+	data := instructionData(CpmmInstructions, CpmmSwapBaseInput, 1000000000, 3500000000)
+
+	// Accounts in layout order: payer, then keys 3 to 14
+	accounts := append([]uint16{0}, accountRange(3, 15)...)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+
+	// The payer spent 1 SOL and its output token account received 3600000000 tokens
+	meta := &TransactionMeta{
+		PreBalances:       make([]uint64, len(keys)),
+		PostBalances:      make([]uint64, len(keys)),
+		PostTokenBalances: []TokenBalance{{AccountIndex: 8, Mint: outputMint, Owner: payer, Amount: 3600000000}},
+	}
+	meta.PreBalances[0] = 2000000000
+	meta.PostBalances[0] = 1000000000
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.Trade) != 1 || len(result.SwapBuys) != 1 {
+		t.Fatalf("Expected 1 trade filed as a buy, got %d trades and %d swap buys", len(result.Trade), len(result.SwapBuys))
+	}
+	trade := result.Trade[0]
+	if !trade.Pool.Equals(pool) || !trade.TokenIn.Equals(solana.SolMint) || !trade.TokenOut.Equals(outputMint) || !trade.Trader.Equals(payer) {
+		t.Errorf("Unexpected accounts: %+v", trade)
+	}
+	if trade.InstructionName != CpmmSwapBaseInput || trade.AmountIn != 1000000000 || trade.AmountOut != 3600000000 {
+		t.Errorf("Unexpected trade: %+v", trade)
+	}
+	if buy := result.SwapBuys[0]; buy.MinAmountOut != 3500000000 || buy.AmountOut != 3600000000 {
+		t.Errorf("Unexpected swap buy: %+v", buy)
+	}
+}
+
+func TestCpmmSwapBaseOutput(t *testing.T) {
+	keys := testKeys(15, solana.NewWallet().PublicKey(), RaydiumCpSwapProgramID)
+	keys[12] = solana.SolMint // input_token_mint
+
+	// max_amount_in 1.1 SOL, amount_out 3600000000 tokens
+	data := instructionData(CpmmInstructions, CpmmSwapBaseOutput, 1100000000, 3600000000)
+	accounts := append([]uint16{0}, accountRange(3, 15)...)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+	result := mustParse(t, encoded, 0, &TransactionMeta{})
+
+	if len(result.Trade) != 1 || len(result.SwapBuys) != 1 {
+		t.Fatalf("Expected 1 trade filed as a buy, got %d trades and %d swap buys", len(result.Trade), len(result.SwapBuys))
+	}
+	if trade := result.Trade[0]; trade.AmountOut != 3600000000 || trade.MaxAmountIn != 1100000000 || trade.MinAmountOut != 0 {
+		t.Errorf("Unexpected trade: %+v", trade)
+	}
+	if buy := result.SwapBuys[0]; buy.MinAmountOut != 0 || buy.MaxAmountIn != 1100000000 || buy.Slippage != 0 {
+		t.Errorf("Unexpected swap buy: %+v", buy)
+	}
+}
+
+func TestCpmmSwapAmountsFromVaults(t *testing.T) {
+	keys := testKeys(15, solana.NewWallet().PublicKey(), RaydiumCpSwapProgramID)
+	keys[12] = solana.SolMint // input_token_mint
+	pool, outputMint := keys[5], keys[13]
+	accounts := append([]uint16{0}, accountRange(3, 15)...)
+
+	// input_vault and output_vault are keys 8 and 9; the payer's own balances aren't in the meta
+	meta := &TransactionMeta{
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 8, Mint: solana.SolMint, Owner: pool, Amount: 50000000000},
+			{AccountIndex: 9, Mint: outputMint, Owner: pool, Amount: 900000000000},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 8, Mint: solana.SolMint, Owner: pool, Amount: 51050000000},
+			{AccountIndex: 9, Mint: outputMint, Owner: pool, Amount: 896400000000},
+		},
+	}
+
+	data := instructionData(CpmmInstructions, CpmmSwapBaseInput, 1050000000, 3500000000)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+	if trade := mustParse(t, encoded, 0, meta).Trade; len(trade) != 1 || trade[0].AmountIn != 1050000000 || trade[0].AmountOut != 3600000000 {
+		t.Errorf("Expected the amount out the output vault paid, got %+v", trade)
+	}
+
+	data = instructionData(CpmmInstructions, CpmmSwapBaseOutput, 1100000000, 3600000000)
+	encoded = encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+	if trade := mustParse(t, encoded, 0, meta).Trade; len(trade) != 1 || trade[0].AmountIn != 1050000000 || trade[0].AmountOut != 3600000000 {
+		t.Errorf("Expected the amount in the input vault took, got %+v", trade)
+	}
+}
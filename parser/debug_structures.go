@@ -1,11 +1,8 @@
-package main
+package parser
 
 import (
 	"encoding/binary"
-	"encoding/json"
 	"fmt"
-	"log"
-	"strings"
 	"time"
 
 	"github.com/gagliardetto/solana-go"
@@ -32,6 +29,7 @@ type AccountDebugInfo struct {
 	IsProgram   bool   `json:"is_program"`
 	IsToken     bool   `json:"is_token"`
 	IsSigner    bool   `json:"is_signer"`
+	IsWritable  bool   `json:"is_writable"`
 }
 
 // InstructionParameters contains parsed parameters from instruction data
@@ -100,15 +98,6 @@ func getEnhancedTokenInfo(tokenMint solana.PublicKey) EnhancedTokenInfo {
 			IsKnown:     true,
 			Description: "USD Coin stablecoin",
 		},
-		"8pf71rxkus6HVhNa9ERdJ571wfPa1a8QKKMsxGkDbonk": {
-			Mint:        "8pf71rxkus6HVhNa9ERdJ571wfPa1a8QKKMsxGkDbonk",
-			Symbol:      "JAMAL", // Based on the transaction context
-			Name:        "Jamal Token",
-			Decimals:    6, // Common default, should be parsed from metadata
-			Supply:      0,
-			IsKnown:     true,
-			Description: "Raydium Launchpad token",
-		},
 	}
 
 	if info, exists := knownTokens[tokenMint.String()]; exists {
@@ -159,6 +148,9 @@ func classifyAccount(account solana.PublicKey) AccountDebugInfo {
 	} else if account.Equals(RaydiumCpSwapProgramID) {
 		info.Description = "Raydium CP Swap Program"
 		info.IsProgram = true
+	} else if account.Equals(RaydiumClmmProgramID) {
+		info.Description = "Raydium CLMM Program"
+		info.IsProgram = true
 	} else if account.Equals(RaydiumV4ProgramID) {
 		info.Description = "Raydium V4 Program"
 		info.IsProgram = true
@@ -168,9 +160,6 @@ func classifyAccount(account solana.PublicKey) AccountDebugInfo {
 	} else if account.String() == "So11111111111111111111111111111111111111112" {
 		info.Description = "SOL (Wrapped SOL)"
 		info.IsToken = true
-	} else if account.String() == "8pf71rxkus6HVhNa9ERdJ571wfPa1a8QKKMsxGkDbonk" {
-		info.Description = "JAMAL Token Mint"
-		info.IsToken = true
 	} else {
 		// Try to determine if it's a token account or pool
 		if len(address) == 44 { // Standard Solana address length
@@ -201,7 +190,7 @@ func createTransactionDebugInfo(tx *Transaction, message *solana.Message) *Trans
 	for i, account := range message.AccountKeys {
 		accountInfo := classifyAccount(account)
 		accountInfo.Index = i
-		accountInfo.IsSigner = (i == 0) // First account is typically the signer
+		accountInfo.IsSigner, accountInfo.IsWritable = messageAccountFlags(message, i)
 		debugInfo.AllAccounts = append(debugInfo.AllAccounts, accountInfo)
 	}
 
@@ -247,6 +236,7 @@ func addInstructionDebugInfo(debugInfo *TransactionDebugInfo, instruction solana
 		if int(accountIndex) < len(message.AccountKeys) {
 			accountInfo := classifyAccount(message.AccountKeys[accountIndex])
 			accountInfo.Index = i
+			accountInfo.IsSigner, accountInfo.IsWritable = messageAccountFlags(message, int(accountIndex))
 			instrInfo.Accounts = append(instrInfo.Accounts, accountInfo)
 		}
 	}
@@ -264,22 +254,6 @@ func addInstructionDebugInfo(debugInfo *TransactionDebugInfo, instruction solana
 	}
 }
 
-// Function to print comprehensive debug info
-func printTransactionDebugInfo(debugInfo *TransactionDebugInfo) {
-	fmt.Println("\n" + strings.Repeat("=", 80))
-	fmt.Println("COMPREHENSIVE TRANSACTION DEBUG INFO")
-	fmt.Println(strings.Repeat("=", 80))
-
-	jsonData, err := json.MarshalIndent(debugInfo, "", "  ")
-	if err != nil {
-		log.Printf("Error marshaling debug info to JSON: %v", err)
-		return
-	}
-
-	fmt.Println(string(jsonData))
-	fmt.Println(strings.Repeat("=", 80))
-}
-
 // Enhanced debug structure for comprehensive instruction debugging
 type ComprehensiveInstructionDebug struct {
 	InstructionIndex int                     `json:"instruction_index"`
@@ -374,11 +348,26 @@ func createInstructionDebugInfo(instruction solana.CompiledInstruction, message
 		parseTokenProgramParameters(&debugInfo.Parameters, instruction.Data)
 	}
 
+	// Launchpad, AMM v4 and CLMM account roles come from the instruction's account layout
+	var layout AccountLayout
+	if programID.Equals(RaydiumLaunchpadV1ProgramID) {
+		if name, ok := LaunchpadInstructions.Lookup(instruction.Data); ok {
+			layout = LaunchpadAccountLayouts[name]
+		}
+	} else if (programID.Equals(RaydiumV4ProgramID) || programID.Equals(RaydiumV5ProgramID)) && len(instruction.Data) > 0 {
+		layout, _ = ammV4Layout(instruction.Data[0], len(instruction.Accounts))
+	} else if programID.Equals(RaydiumClmmProgramID) {
+		if name, ok := ClmmInstructions.Lookup(instruction.Data); ok {
+			layout = ClmmAccountLayouts[name]
+		}
+	}
+
 	// Process all accounts with comprehensive info
 	for i, accountIndex := range instruction.Accounts {
 		if int(accountIndex) < len(message.AccountKeys) {
 			account := message.AccountKeys[accountIndex]
-			accountInfo := createDetailedAccountInfo(account, i, int(accountIndex), programID)
+			signer, writable := messageAccountFlags(message, int(accountIndex))
+			accountInfo := createDetailedAccountInfo(account, i, signer, writable, programID, layout)
 			debugInfo.Accounts = append(debugInfo.Accounts, accountInfo)
 		}
 	}
@@ -397,6 +386,8 @@ func getProgramName(programID solana.PublicKey) string {
 		return "Raydium Launchpad V1"
 	case RaydiumCpSwapProgramID:
 		return "Raydium CP Swap"
+	case RaydiumClmmProgramID:
+		return "Raydium CLMM"
 	case RaydiumStakingProgramID:
 		return "Raydium Staking"
 	case RaydiumLiquidityProgramID:
@@ -413,7 +404,7 @@ func getProgramName(programID solana.PublicKey) string {
 }
 
 // Function to create detailed account info with all 18 fields
-func createDetailedAccountInfo(account solana.PublicKey, instructionIndex int, accountIndex int, programID solana.PublicKey) DetailedAccountInfo {
+func createDetailedAccountInfo(account solana.PublicKey, instructionIndex int, signer, writable bool, programID solana.PublicKey, layout AccountLayout) DetailedAccountInfo {
 	address := account.String()
 
 	info := DetailedAccountInfo{
@@ -424,8 +415,8 @@ func createDetailedAccountInfo(account solana.PublicKey, instructionIndex int, a
 		IsSystem:      false,
 		IsProgram:     false,
 		IsToken:       false,
-		IsSigner:      (accountIndex == 0), // First account is typically the signer
-		IsWritable:    false,
+		IsSigner:      signer,
+		IsWritable:    writable,
 		IsExecutable:  false,
 		IsOwner:       false,
 		IsRentExempt:  false,
@@ -466,6 +457,11 @@ func createDetailedAccountInfo(account solana.PublicKey, instructionIndex int, a
 		info.Role = "cpswap_program"
 		info.IsProgram = true
 		info.IsExecutable = true
+	} else if account.Equals(RaydiumClmmProgramID) {
+		info.Description = "Raydium CLMM Program"
+		info.Role = "clmm_program"
+		info.IsProgram = true
+		info.IsExecutable = true
 	} else if account.Equals(RaydiumV4ProgramID) {
 		info.Description = "Raydium V4 Program"
 		info.Role = "raydium_v4_program"
@@ -482,86 +478,36 @@ func createDetailedAccountInfo(account solana.PublicKey, instructionIndex int, a
 		info.IsToken = true
 		info.TokenMint = address
 		info.TokenDecimals = 9
-	} else if account.String() == "8pf71rxkus6HVhNa9ERdJ571wfPa1a8QKKMsxGkDbonk" {
-		info.Description = "JAMAL Token Mint"
-		info.Role = "token_mint"
-		info.IsToken = true
-		info.TokenMint = address
-		info.TokenDecimals = 6
 	} else {
 		// Try to determine role based on context
-		if programID.Equals(RaydiumLaunchpadV1ProgramID) {
-			info.Role = determineRaydiumLaunchpadRole(instructionIndex, account)
-		} else if programID.Equals(RaydiumV4ProgramID) || programID.Equals(RaydiumV5ProgramID) {
-			info.Role = determineRaydiumV4V5Role(instructionIndex, account)
+		if programID.Equals(RaydiumLaunchpadV1ProgramID) || programID.Equals(RaydiumV4ProgramID) || programID.Equals(RaydiumV5ProgramID) {
+			info.Role = determineLayoutRole(layout, instructionIndex)
 		} else {
 			info.Role = "user_account"
 		}
 		info.Description = fmt.Sprintf("Account (%s)", info.Role)
-		info.IsWritable = true // Most user accounts are writable
 	}
 
 	return info
 }
 
-// Function to determine role in Raydium Launchpad instructions
-func determineRaydiumLaunchpadRole(accountIndex int, account solana.PublicKey) string {
-	switch accountIndex {
-	case 0:
-		return "signer/creator"
-	case 1:
-		return "token_mint"
-	case 2:
-		return "pool"
-	case 3:
-		return "user_token_account"
-	case 4:
-		return "user_sol_account"
-	case 5:
-		return "pool_sol_account"
-	case 6:
-		return "pool_token_account"
-	case 7:
-		return "system_program"
-	case 8:
-		return "token_program"
-	case 9:
-		return "associated_token_program"
-	default:
-		return "additional_account"
-	}
-}
-
-// Function to determine role in Raydium V4/V5 instructions
-func determineRaydiumV4V5Role(accountIndex int, account solana.PublicKey) string {
-	switch accountIndex {
-	case 0:
-		return "signer/trader"
-	case 1:
-		return "user_source_account"
-	case 2:
-		return "user_dest_account"
-	case 3:
-		return "pool_source_account"
-	case 4:
-		return "pool_dest_account"
-	case 5:
-		return "pool_authority"
-	case 6:
-		return "amm_pool"
-	case 7:
-		return "amm_authority"
-	case 8:
-		return "amm_open_orders"
-	case 9:
-		return "amm_target_orders"
-	default:
-		return "additional_account"
+// Function to determine an account's role from the instruction's account layout
+func determineLayoutRole(layout AccountLayout, accountIndex int) string {
+	if accountIndex < len(layout) {
+		return layout[accountIndex]
 	}
+	return "additional_account"
 }
 
 // Function to parse Raydium Launchpad parameters
 func parseRaydiumLaunchpadParameters(params *ComprehensiveParameters, data []byte) {
+	if idl, ok := GetIDL(RaydiumLaunchpadV1ProgramID); ok {
+		if decoded, err := idl.DecodeInstructionData(data); err == nil {
+			mapLaunchpadParameters(params, decoded)
+			return
+		}
+	}
+
 	// For Launchpad transactions, we need to skip the discriminator
 	dataStart := 1
 	if len(data) >= 8 {
@@ -580,6 +526,40 @@ func parseRaydiumLaunchpadParameters(params *ComprehensiveParameters, data []byt
 	params.Direction = "buy" // Default for Launchpad
 }
 
+// Function to map IDL-decoded Launchpad args onto the debug parameters
+func mapLaunchpadParameters(params *ComprehensiveParameters, decoded *DecodedInstruction) {
+	args := decoded.Args
+	params.Amount = args.Uint64("amount_in")
+	params.AmountOut = args.Uint64("amount_out")
+	params.MaxAmount = args.Uint64("maximum_amount_in")
+	params.MinAmount = args.Uint64("minimum_amount_out")
+	if params.Amount == 0 {
+		params.Amount = params.AmountOut
+	}
+
+	if mintParams := args.Struct("base_mint_param"); mintParams != nil {
+		params.Decimals = mintParams.Uint8("decimals")
+	}
+
+	switch decoded.Name {
+	case LaunchpadBuyExactIn, LaunchpadBuyExactOut:
+		params.Direction = "buy"
+	case LaunchpadSellExactIn, LaunchpadSellExactOut:
+		params.Direction = "sell"
+	case LaunchpadInitialize, LaunchpadInitializeV2, LaunchpadInitializeWithToken2022:
+		params.Direction = "create"
+	case LaunchpadMigrateToAmm, LaunchpadMigrateToCpswap:
+		params.Direction = "migrate"
+	default:
+		params.Direction = decoded.Name
+	}
+
+	params.ExtraParams["instruction"] = decoded.Name
+	for name, value := range args {
+		params.ExtraParams[name] = value
+	}
+}
+
 // Function to parse Raydium V4/V5 parameters
 func parseRaydiumV4V5Parameters(params *ComprehensiveParameters, data []byte) {
 	if len(data) >= 9 {
@@ -602,69 +582,3 @@ func parseTokenProgramParameters(params *ComprehensiveParameters, data []byte) {
 		params.AmountOut = binary.LittleEndian.Uint64(data[9:17])
 	}
 }
-
-// Function to print comprehensive instruction debug info
-func printInstructionDebugInfo(debugInfo *ComprehensiveInstructionDebug) {
-	fmt.Printf("\n%s\n", strings.Repeat("=", 100))
-	fmt.Printf("INSTRUCTION DEBUG INFO - Index: %d | Program: %s\n", debugInfo.InstructionIndex, debugInfo.ProgramName)
-	fmt.Printf("%s\n", strings.Repeat("=", 100))
-
-	// Print basic instruction info
-	fmt.Printf("Program ID: %s\n", debugInfo.ProgramID)
-	fmt.Printf("Discriminator: %s\n", debugInfo.Discriminator)
-	fmt.Printf("Data Length: %d bytes\n", debugInfo.DataLength)
-	fmt.Printf("Data Hex: %s\n", debugInfo.DataHex)
-	fmt.Printf("Account Count: %d\n", debugInfo.AccountCount)
-	fmt.Printf("Timestamp: %d\n", debugInfo.Timestamp)
-
-	// Print all account details
-	fmt.Printf("\nALL ACCOUNT DETAILS (18 fields per account):\n")
-	fmt.Printf("%s\n", strings.Repeat("-", 100))
-	for i, account := range debugInfo.Accounts {
-		fmt.Printf("Account %d:\n", i)
-		fmt.Printf("  Address: %s\n", account.Address)
-		fmt.Printf("  Description: %s\n", account.Description)
-		fmt.Printf("  Role: %s\n", account.Role)
-		fmt.Printf("  IsSystem: %t\n", account.IsSystem)
-		fmt.Printf("  IsProgram: %t\n", account.IsProgram)
-		fmt.Printf("  IsToken: %t\n", account.IsToken)
-		fmt.Printf("  IsSigner: %t\n", account.IsSigner)
-		fmt.Printf("  IsWritable: %t\n", account.IsWritable)
-		fmt.Printf("  IsExecutable: %t\n", account.IsExecutable)
-		fmt.Printf("  IsOwner: %t\n", account.IsOwner)
-		fmt.Printf("  IsRentExempt: %t\n", account.IsRentExempt)
-		fmt.Printf("  Balance: %d\n", account.Balance)
-		fmt.Printf("  DataSize: %d\n", account.DataSize)
-		fmt.Printf("  TokenMint: %s\n", account.TokenMint)
-		fmt.Printf("  TokenOwner: %s\n", account.TokenOwner)
-		fmt.Printf("  TokenAmount: %d\n", account.TokenAmount)
-		fmt.Printf("  TokenDecimals: %d\n", account.TokenDecimals)
-		fmt.Printf("%s\n", strings.Repeat("-", 50))
-	}
-
-	// Print parsed parameters
-	fmt.Printf("\nPARSED PARAMETERS:\n")
-	fmt.Printf("Amount: %d\n", debugInfo.Parameters.Amount)
-	fmt.Printf("AmountOut: %d\n", debugInfo.Parameters.AmountOut)
-	fmt.Printf("MaxAmount: %d\n", debugInfo.Parameters.MaxAmount)
-	fmt.Printf("MinAmount: %d\n", debugInfo.Parameters.MinAmount)
-	fmt.Printf("Decimals: %d\n", debugInfo.Parameters.Decimals)
-	fmt.Printf("Direction: %s\n", debugInfo.Parameters.Direction)
-	fmt.Printf("TokenIn: %s\n", debugInfo.Parameters.TokenIn)
-	fmt.Printf("TokenOut: %s\n", debugInfo.Parameters.TokenOut)
-	fmt.Printf("Pool: %s\n", debugInfo.Parameters.Pool)
-	fmt.Printf("Creator: %s\n", debugInfo.Parameters.Creator)
-	fmt.Printf("Trader: %s\n", debugInfo.Parameters.Trader)
-	fmt.Printf("Slippage: %.4f\n", debugInfo.Parameters.Slippage)
-
-	// Print full JSON
-	fmt.Printf("\nFULL JSON OUTPUT:\n")
-	jsonData, err := json.MarshalIndent(debugInfo, "", "  ")
-	if err != nil {
-		log.Printf("Error marshaling debug info to JSON: %v", err)
-	} else {
-		fmt.Println(string(jsonData))
-	}
-
-	fmt.Printf("\n%s\n", strings.Repeat("=", 100))
-}
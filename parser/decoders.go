@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// InstructionContext is an instruction to decode along with where it sits in its transaction.
+// Message is set for RPC transactions and GeyserTx for Yellowstone updates.
+type InstructionContext struct {
+	Index     int // Top-level instruction; inner instructions carry the index of the instruction that invoked them
+	ProgramID solana.PublicKey
+	Data      []byte
+	Accounts  []solana.PublicKey // Instruction accounts, lookup tables resolved
+	Signer    solana.PublicKey   // First account of the instruction that signed, else the fee payer
+	Meta      *TransactionMeta   // nil when parsed without meta
+
+	Instruction solana.CompiledInstruction
+	Message     *solana.Message
+	Geyser      GeyserInstruction
+	GeyserTx    *GeyserTransaction
+
+	decodedBy *Parser
+}
+
+// newInstructionContext builds the context of an instruction of an RPC transaction
+func (p *Parser) newInstructionContext(instruction solana.CompiledInstruction, message *solana.Message, index int, meta *TransactionMeta) *InstructionContext {
+	return &InstructionContext{
+		Index:       index,
+		ProgramID:   message.AccountKeys[instruction.ProgramIDIndex],
+		Data:        instruction.Data,
+		Accounts:    instructionAccounts(instruction, message),
+		Signer:      instructionSigner(instruction, message),
+		Meta:        meta,
+		Instruction: instruction,
+		Message:     message,
+		decodedBy:   p,
+	}
+}
+
+// newGeyserInstructionContext builds the context of an instruction of a Yellowstone transaction
+func (p *Parser) newGeyserInstructionContext(instruction GeyserInstruction, index int, geyserTx *GeyserTransaction) *InstructionContext {
+	return &InstructionContext{
+		Index:     index,
+		ProgramID: instruction.ProgramID,
+		Data:      instruction.Data,
+		Accounts:  instruction.Accounts,
+		Signer:    geyserInstructionSigner(instruction, geyserTx.AccountKeys),
+		Meta:      geyserTx.Meta,
+		Geyser:    instruction,
+		GeyserTx:  geyserTx,
+		decodedBy: p,
+	}
+}
+
+// AccountKeys returns the full account list of the transaction
+func (c *InstructionContext) AccountKeys() []solana.PublicKey {
+	if c.Message != nil {
+		return c.Message.AccountKeys
+	}
+	if c.GeyserTx != nil {
+		return c.GeyserTx.AccountKeys
+	}
+	return nil
+}
+
+// parser returns the parser decoding the instruction, or the default one for contexts built by hand
+func (c *InstructionContext) parser() *Parser {
+	if c.decodedBy == nil {
+		return defaultParser()
+	}
+	return c.decodedBy
+}
+
+// tokens indexes the token balances of the transaction by token account
+func (c *InstructionContext) tokens() tokenAccounts {
+	return newTokenAccounts(c.AccountKeys(), c.Meta)
+}
+
+// ProgramDecoder decodes the instructions of one or more programs into the parsed transaction
+type ProgramDecoder interface {
+	// ProgramIDs returns the programs the decoder handles
+	ProgramIDs() []solana.PublicKey
+	// Decode adds what the instruction did to result
+	Decode(ctx *InstructionContext, result *Transaction) error
+}
+
+type programDecoderFunc struct {
+	programIDs []solana.PublicKey
+	decode     func(ctx *InstructionContext, result *Transaction) error
+}
+
+func (d programDecoderFunc) ProgramIDs() []solana.PublicKey { return d.programIDs }
+
+func (d programDecoderFunc) Decode(ctx *InstructionContext, result *Transaction) error {
+	return d.decode(ctx, result)
+}
+
+// NewProgramDecoder returns a ProgramDecoder that decodes the instructions of programIDs with decode
+func NewProgramDecoder(decode func(ctx *InstructionContext, result *Transaction) error, programIDs ...solana.PublicKey) ProgramDecoder {
+	return programDecoderFunc{programIDs: programIDs, decode: decode}
+}
+
+// DecoderRegistry maps program IDs to the decoder of their instructions
+type DecoderRegistry struct {
+	mu       sync.RWMutex
+	decoders map[solana.PublicKey]ProgramDecoder
+}
+
+// NewDecoderRegistry builds a registry from the given decoders
+func NewDecoderRegistry(decoders ...ProgramDecoder) *DecoderRegistry {
+	r := &DecoderRegistry{decoders: make(map[solana.PublicKey]ProgramDecoder)}
+	for _, decoder := range decoders {
+		r.Register(decoder)
+	}
+	return r
+}
+
+// Register adds a decoder for each of its programs, replacing the decoder registered before
+func (r *DecoderRegistry) Register(decoder ProgramDecoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, programID := range decoder.ProgramIDs() {
+		r.decoders[programID] = decoder
+	}
+}
+
+// clone returns a registry with the same decoders
+func (r *DecoderRegistry) clone() *DecoderRegistry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c := &DecoderRegistry{decoders: make(map[solana.PublicKey]ProgramDecoder, len(r.decoders))}
+	for programID, decoder := range r.decoders {
+		c.decoders[programID] = decoder
+	}
+	return c
+}
+
+// Lookup returns the decoder registered for a program
+func (r *DecoderRegistry) Lookup(programID solana.PublicKey) (ProgramDecoder, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	decoder, ok := r.decoders[programID]
+	return decoder, ok
+}
+
+// DefaultProgramDecoders returns the decoders of the Raydium programs and the system programs they rely on
+func DefaultProgramDecoders() []ProgramDecoder {
+	return []ProgramDecoder{
+		NewProgramDecoder(decodeAmmV4, RaydiumV4ProgramID, RaydiumV5ProgramID),
+		NewProgramDecoder(decodeLaunchpad, RaydiumLaunchpadV1ProgramID),
+		NewProgramDecoder(decodeCpmm, RaydiumCpSwapProgramID),
+		NewProgramDecoder(decodeClmm, RaydiumClmmProgramID),
+		NewProgramDecoder(decodeStaking, RaydiumStakingProgramID),
+		NewProgramDecoder(decodeLiquidity, RaydiumLiquidityProgramID),
+		NewProgramDecoder(decodeUnknownRaydium, RaydiumUnknownProgramID1),
+		NewProgramDecoder(decodeTokenProgram, TokenProgramID, Token2022ProgramID),
+		NewProgramDecoder(decodeComputeBudget, ComputeBudgetProgramID),
+		NewProgramDecoder(decodeSystemTransfer, SystemProgramID),
+	}
+}
+
+// RegisterProgramDecoder adds a decoder to the default parser, e.g. for a router program, replacing the
+// decoder of any program it also handles. Like the other setters it swaps in a reconfigured copy, so the
+// registry of a parse in progress doesn't change under it.
+func RegisterProgramDecoder(decoder ProgramDecoder) {
+	configureDefaultParser(func(p *Parser) {
+		decoders := p.decoders.clone()
+		decoders.Register(decoder)
+		p.decoders = decoders
+	})
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestRegisteredDecoderReceivesInstructions(t *testing.T) {
+	router := solana.NewWallet().PublicKey()
+	payer, pool := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	var seen []*InstructionContext
+	RegisterProgramDecoder(NewProgramDecoder(func(ctx *InstructionContext, result *Transaction) error {
+		seen = append(seen, ctx)
+		result.Trade = append(result.Trade, TradeInfo{InstructionIndex: ctx.Index, Pool: ctx.Accounts[1], Trader: ctx.Signer, TradeType: "swap"})
+		return nil
+	}, router))
+
+	encoded := encodeTransaction(t, solana.PublicKeySlice{payer, pool, router}, 1,
+		solana.CompiledInstruction{ProgramIDIndex: 2, Accounts: []uint16{0, 1}, Data: []byte{1, 2, 3}})
+	result := mustParse(t, encoded, 0, &TransactionMeta{})
+
+	if len(seen) != 1 {
+		t.Fatalf("Expected the decoder to be called once, got %d", len(seen))
+	}
+	if ctx := seen[0]; !ctx.ProgramID.Equals(router) || !ctx.Signer.Equals(payer) || len(ctx.Accounts) != 2 || ctx.Message == nil || ctx.Meta == nil {
+		t.Errorf("Unexpected context: %+v", ctx)
+	}
+	if len(result.Trade) != 1 || !result.Trade[0].Pool.Equals(pool) || !result.Trade[0].Trader.Equals(payer) {
+		t.Errorf("Expected the decoder's trade in the result, got %+v", result.Trade)
+	}
+}
+
+func TestDecoderRegistryReplacesPrograms(t *testing.T) {
+	registry := NewDecoderRegistry(DefaultProgramDecoders()...)
+	if _, ok := registry.Lookup(RaydiumLaunchpadV1ProgramID); !ok {
+		t.Fatal("Expected a default Launchpad decoder")
+	}
+
+	called := false
+	registry.Register(NewProgramDecoder(func(ctx *InstructionContext, result *Transaction) error {
+		called = true
+		return nil
+	}, RaydiumLaunchpadV1ProgramID))
+
+	decoder, _ := registry.Lookup(RaydiumLaunchpadV1ProgramID)
+	decoder.Decode(&InstructionContext{ProgramID: RaydiumLaunchpadV1ProgramID}, &Transaction{})
+	if !called {
+		t.Error("Expected the registered decoder to replace the default one")
+	}
+}
+
+func TestRegisterProgramDecoderSwapsInACopy(t *testing.T) {
+	router := solana.NewWallet().PublicKey()
+	before := defaultParser()
+
+	RegisterProgramDecoder(NewProgramDecoder(func(ctx *InstructionContext, result *Transaction) error { return nil }, router))
+
+	if _, ok := before.decoders.Lookup(router); ok {
+		t.Error("Expected the registry of the previous default parser to be left alone")
+	}
+	if _, ok := defaultParser().decoders.Lookup(router); !ok {
+		t.Error("Expected the default parser to decode the registered program")
+	}
+	if _, ok := defaultParser().decoders.Lookup(RaydiumLaunchpadV1ProgramID); !ok {
+		t.Error("Expected the default decoders to be kept")
+	}
+}
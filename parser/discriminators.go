@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Raydium Launchpad (LaunchLab) instruction names as declared in the program IDL
+const (
+	LaunchpadInitialize                = "initialize"
+	LaunchpadInitializeV2              = "initialize_v2"
+	LaunchpadInitializeWithToken2022   = "initialize_with_token_2022"
+	LaunchpadBuyExactIn                = "buy_exact_in"
+	LaunchpadBuyExactOut               = "buy_exact_out"
+	LaunchpadSellExactIn               = "sell_exact_in"
+	LaunchpadSellExactOut              = "sell_exact_out"
+	LaunchpadMigrateToAmm              = "migrate_to_amm"
+	LaunchpadMigrateToCpswap           = "migrate_to_cpswap"
+	LaunchpadClaimPlatformFee          = "claim_platform_fee"
+	LaunchpadClaimPlatformFeeFromVault = "claim_platform_fee_from_vault"
+	LaunchpadClaimCreatorFee           = "claim_creator_fee"
+	LaunchpadCreateVestingAccount      = "create_vesting_account"
+	LaunchpadClaimVestedToken          = "claim_vested_token"
+	LaunchpadCollectFee                = "collect_fee"
+	LaunchpadCollectMigrateFee         = "collect_migrate_fee"
+	LaunchpadCreateConfig              = "create_config"
+	LaunchpadUpdateConfig              = "update_config"
+	LaunchpadCreatePlatformConfig      = "create_platform_config"
+	LaunchpadUpdatePlatformConfig      = "update_platform_config"
+	LaunchpadUpdatePlatformCurveParam  = "update_platform_curve_param"
+	LaunchpadRemovePlatformCurveParam  = "remove_platform_curve_param"
+)
+
+// Discriminator is the 8-byte prefix Anchor programs put in front of instruction, account and event data
+type Discriminator [8]byte
+
+// String returns the discriminator as a hex string
+func (d Discriminator) String() string {
+	return hex.EncodeToString(d[:])
+}
+
+// AnchorDiscriminator computes sha256("<namespace>:<name>")[:8], e.g. namespace "global" for instructions
+func AnchorDiscriminator(namespace, name string) Discriminator {
+	var d Discriminator
+	sum := sha256.Sum256([]byte(namespace + ":" + name))
+	copy(d[:], sum[:8])
+	return d
+}
+
+// AnchorEventCPITag prefixes the data of the self-invocation Anchor programs use to emit events (emit_cpi!)
+var AnchorEventCPITag = AnchorDiscriminator("anchor", "event")
+
+// isAnchorEventCPI reports whether instruction data is an emit_cpi event rather than a real instruction
+func isAnchorEventCPI(data []byte) bool {
+	return len(data) >= 8 && Discriminator(data[:8]) == AnchorEventCPITag
+}
+
+// DiscriminatorRegistry maps the Anchor instruction discriminators of a program to their names
+type DiscriminatorRegistry struct {
+	ProgramID solana.PublicKey
+	byDisc    map[Discriminator]string
+	byName    map[string]Discriminator
+}
+
+// NewDiscriminatorRegistry builds a registry for the given program from its instruction names
+func NewDiscriminatorRegistry(programID solana.PublicKey, names ...string) *DiscriminatorRegistry {
+	r := &DiscriminatorRegistry{
+		ProgramID: programID,
+		byDisc:    make(map[Discriminator]string, len(names)),
+		byName:    make(map[string]Discriminator, len(names)),
+	}
+	for _, name := range names {
+		r.Register(name, AnchorDiscriminator("global", name))
+	}
+	return r
+}
+
+// Register adds an instruction name with an explicit discriminator
+func (r *DiscriminatorRegistry) Register(name string, disc Discriminator) {
+	r.byDisc[disc] = name
+	r.byName[name] = disc
+}
+
+// Lookup returns the instruction name matching the first 8 bytes of data
+func (r *DiscriminatorRegistry) Lookup(data []byte) (string, bool) {
+	if len(data) < 8 {
+		return "", false
+	}
+	var d Discriminator
+	copy(d[:], data[:8])
+	name, ok := r.byDisc[d]
+	return name, ok
+}
+
+// Discriminator returns the discriminator registered for an instruction name
+func (r *DiscriminatorRegistry) Discriminator(name string) (Discriminator, bool) {
+	d, ok := r.byName[name]
+	return d, ok
+}
+
+// Names returns every registered instruction name
+func (r *DiscriminatorRegistry) Names() []string {
+	names := make([]string, 0, len(r.byName))
+	for name := range r.byName {
+		names = append(names, name)
+	}
+	return names
+}
+
+// LaunchpadInstructions holds the real discriminators of every Raydium Launchpad instruction
+var LaunchpadInstructions = NewDiscriminatorRegistry(RaydiumLaunchpadV1ProgramID,
+	LaunchpadInitialize,
+	LaunchpadInitializeV2,
+	LaunchpadInitializeWithToken2022,
+	LaunchpadBuyExactIn,
+	LaunchpadBuyExactOut,
+	LaunchpadSellExactIn,
+	LaunchpadSellExactOut,
+	LaunchpadMigrateToAmm,
+	LaunchpadMigrateToCpswap,
+	LaunchpadClaimPlatformFee,
+	LaunchpadClaimPlatformFeeFromVault,
+	LaunchpadClaimCreatorFee,
+	LaunchpadCreateVestingAccount,
+	LaunchpadClaimVestedToken,
+	LaunchpadCollectFee,
+	LaunchpadCollectMigrateFee,
+	LaunchpadCreateConfig,
+	LaunchpadUpdateConfig,
+	LaunchpadCreatePlatformConfig,
+	LaunchpadUpdatePlatformConfig,
+	LaunchpadUpdatePlatformCurveParam,
+	LaunchpadRemovePlatformCurveParam,
+)
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestAnchorDiscriminator(t *testing.T) {
+	// Discriminators published in the Raydium Launchpad IDL
+	testCases := map[string]string{
+		LaunchpadBuyExactIn:   "faea0d7bd59c13ec",
+		LaunchpadSellExactIn:  "9527de9bd37c981a",
+		LaunchpadInitialize:   "afaf6d1f0d989bed",
+		LaunchpadMigrateToAmm: "cf52c091fecf91df",
+	}
+
+	for name, expected := range testCases {
+		if got := AnchorDiscriminator("global", name).String(); got != expected {
+			t.Errorf("Expected discriminator %s for %s, got %s", expected, name, got)
+		}
+	}
+}
+
+func TestLaunchpadRegistryLookup(t *testing.T) {
+	for _, name := range LaunchpadInstructions.Names() {
+		disc, ok := LaunchpadInstructions.Discriminator(name)
+		if !ok {
+			t.Fatalf("Missing discriminator for %s", name)
+		}
+
+		data := append(disc[:], 0x01, 0x02)
+		got, ok := LaunchpadInstructions.Lookup(data)
+		if !ok || got != name {
+			t.Errorf("Expected lookup of %s to return %s, got %q", disc, name, got)
+		}
+	}
+
+	if _, ok := LaunchpadInstructions.Lookup([]byte{0x1b, 0x4c, 0x5d, 0x6e, 0x7f, 0x8a, 0x9b, 0x0c}); ok {
+		t.Errorf("Expected made-up discriminator to be unknown")
+	}
+}
+
+func TestLaunchpadBuyCarriesInstructionName(t *testing.T) {
+	disc, _ := LaunchpadInstructions.Discriminator(LaunchpadBuyExactIn)
+	data := make([]byte, 32)
+	copy(data, disc[:])
+	binary.LittleEndian.PutUint64(data[8:16], 1000000000)
+
+	message := &solana.Message{
+		AccountKeys: []solana.PublicKey{
+			solana.MustPublicKeyFromBase58("DcyrgE2gusF35moZDMVnjED7jfXBuQeJgjG2oEgocYWd"),
+			solana.MustPublicKeyFromBase58("7ADJ8pYiWJA4gu2sC6VtXJ1EhbRzH4kavktmsHMfa91P"),
+			solana.MustPublicKeyFromBase58("6s1xP3hpbAfFoNtUNF8mfHsjr2Bd97JxFJRWLbL6aHuX"),
+			RaydiumLaunchpadV1ProgramID,
+		},
+	}
+	instruction := solana.CompiledInstruction{
+		ProgramIDIndex: 3,
+		Accounts:       []uint16{0, 1, 2},
+		Data:           data,
+	}
+
+	result := &Transaction{}
+	if err := defaultParser().parseRaydiumLaunchpadInstructionStandard(instruction, message, 0, result, nil); err != nil {
+		t.Fatalf("Failed to parse buy instruction: %v", err)
+	}
+
+	if len(result.Trade) != 1 {
+		t.Fatalf("Expected 1 trade, got %d", len(result.Trade))
+	}
+	if result.Trade[0].InstructionName != LaunchpadBuyExactIn {
+		t.Errorf("Expected instruction name %s, got %s", LaunchpadBuyExactIn, result.Trade[0].InstructionName)
+	}
+	if result.Trade[0].AmountIn != 1000000000 {
+		t.Errorf("Expected amount in 1000000000, got %d", result.Trade[0].AmountIn)
+	}
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Reasons an instruction couldn't be decoded, matched with errors.Is against the Diagnostics of a Transaction.
+// ErrUndecodableTransaction is returned by the parse entry points in StrictParsing mode.
+var (
+	ErrUnknownDiscriminator    = errors.New("unknown instruction discriminator")
+	ErrAccountIndexOutOfRange  = errors.New("account index out of range")
+	ErrInsufficientAccounts    = errors.New("insufficient accounts")
+	ErrInstructionDataTooShort = errors.New("instruction data too short")
+	ErrMalformedData           = errors.New("malformed instruction data")
+	ErrUnresolvedLookupTables  = errors.New("unresolved address lookup tables")
+	ErrUndecodableTransaction  = errors.New("undecodable transaction")
+)
+
+// ParseError is an instruction that couldn't be decoded, along with where it sits in its transaction
+type ParseError struct {
+	InstructionIndex int              // Top-level instruction; -1 for errors about the whole transaction
+	InnerIndex       int              // Position among the inner instructions of InstructionIndex; -1 for the top-level instruction
+	ProgramID        solana.PublicKey // Zero if the program couldn't be resolved
+	Discriminator    []byte           // Leading bytes of the instruction data, up to 8
+	Err              error            // The reason, wrapping one of the Err* sentinels where one applies
+}
+
+func (e *ParseError) Error() string {
+	if e.InstructionIndex < 0 {
+		return e.Err.Error()
+	}
+	position := fmt.Sprintf("instruction %d", e.InstructionIndex)
+	if e.InnerIndex >= 0 {
+		position = fmt.Sprintf("inner instruction %d.%d", e.InstructionIndex, e.InnerIndex)
+	}
+	if e.ProgramID.IsZero() {
+		return fmt.Sprintf("%s: %v", position, e.Err)
+	}
+	return fmt.Sprintf("%s (program %s, discriminator %x): %v", position, e.ProgramID, e.Discriminator, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON writes the reason as its message, since most errors have no exported fields
+func (e *ParseError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		InstructionIndex int
+		InnerIndex       int
+		ProgramID        solana.PublicKey
+		Discriminator    string
+		Reason           string
+	}{e.InstructionIndex, e.InnerIndex, e.ProgramID, fmt.Sprintf("%x", e.Discriminator), e.Err.Error()})
+}
+
+// newParseError attaches the position of an instruction to the error its decoder returned
+func newParseError(err error, index, innerIndex int, programID solana.PublicKey, data []byte) *ParseError {
+	return &ParseError{
+		InstructionIndex: index,
+		InnerIndex:       innerIndex,
+		ProgramID:        programID,
+		Discriminator:    append([]byte(nil), data[:min(8, len(data))]...),
+		Err:              err,
+	}
+}
+
+// addDiagnostic logs a parse error and adds it to the diagnostics of the transaction
+func (p *Parser) addDiagnostic(result *Transaction, err *ParseError) {
+	p.logger.Printf("Parse error: %v", err)
+	result.Diagnostics = append(result.Diagnostics, err)
+}
+
+// DiagnosticsErr joins the diagnostics of the transaction into one error, or returns nil if every
+// instruction was decoded. The result matches the Err* sentinels and *ParseError with errors.Is and errors.As.
+func (t *Transaction) DiagnosticsErr() error {
+	errs := make([]error, len(t.Diagnostics))
+	for i, err := range t.Diagnostics {
+		errs[i] = err
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestDiagnosticsCollectParseErrors(t *testing.T) {
+	payer, pool := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	swap := append([]byte{AMM_V4_SWAP_BASE_IN}, make([]byte, 16)...)
+	encoded := encodeTransaction(t, solana.PublicKeySlice{payer, pool, RaydiumV4ProgramID, RaydiumCpSwapProgramID}, 2,
+		solana.CompiledInstruction{ProgramIDIndex: 2, Accounts: []uint16{0, 1}, Data: swap},
+		solana.CompiledInstruction{ProgramIDIndex: 3, Accounts: []uint16{0, 1}, Data: []byte{1, 2, 3, 4, 5, 6, 7, 8}})
+
+	meta := &TransactionMeta{InnerInstructions: []InnerInstructionSet{{Index: 1, Instructions: []InnerInstruction{
+		{Instruction: solana.CompiledInstruction{ProgramIDIndex: 3, Accounts: []uint16{0, 9}}},
+	}}}}
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.Diagnostics) != 3 {
+		t.Fatalf("Expected 3 diagnostics, got %d: %v", len(result.Diagnostics), result.Diagnostics)
+	}
+
+	swapErr := result.Diagnostics[0]
+	if !errors.Is(swapErr, ErrInsufficientAccounts) {
+		t.Errorf("Expected insufficient accounts, got %v", swapErr)
+	}
+	if swapErr.InstructionIndex != 0 || swapErr.InnerIndex != -1 || !swapErr.ProgramID.Equals(RaydiumV4ProgramID) ||
+		len(swapErr.Discriminator) != 8 || swapErr.Discriminator[0] != AMM_V4_SWAP_BASE_IN {
+		t.Errorf("Unexpected position of the swap error: %+v", swapErr)
+	}
+
+	if unknown := result.Diagnostics[1]; !errors.Is(unknown, ErrUnknownDiscriminator) || unknown.InstructionIndex != 1 {
+		t.Errorf("Expected an unknown discriminator at instruction 1, got %v", unknown)
+	}
+
+	inner := result.Diagnostics[2]
+	if !errors.Is(inner, ErrAccountIndexOutOfRange) || inner.InstructionIndex != 1 || inner.InnerIndex != 0 {
+		t.Errorf("Expected an out of range account at inner instruction 1.0, got %v", inner)
+	}
+	if !strings.HasPrefix(inner.Error(), "inner instruction 1.0 (program "+RaydiumCpSwapProgramID.String()) {
+		t.Errorf("Unexpected message: %s", inner.Error())
+	}
+
+	joined := result.DiagnosticsErr()
+	var parseErr *ParseError
+	if !errors.As(joined, &parseErr) || parseErr != swapErr {
+		t.Errorf("Expected errors.As to find the first diagnostic, got %v", parseErr)
+	}
+	if !errors.Is(joined, ErrAccountIndexOutOfRange) {
+		t.Error("Expected the joined diagnostics to match ErrAccountIndexOutOfRange")
+	}
+	if (&Transaction{}).DiagnosticsErr() != nil {
+		t.Error("Expected no error without diagnostics")
+	}
+}
+
+func TestTruncatedLaunchpadInstructionsAreMalformed(t *testing.T) {
+	geyserParsers := map[string]func(GeyserInstruction, int, string, solana.PublicKey, *Transaction) error{
+		LaunchpadInitialize:  defaultParser().parseGeyserCreatePoolInstruction,
+		LaunchpadBuyExactIn:  defaultParser().parseGeyserBuyInstruction,
+		LaunchpadSellExactIn: defaultParser().parseGeyserSellInstruction,
+	}
+
+	for name, parse := range geyserParsers {
+		// The discriminator and half of the first argument
+		disc, _ := LaunchpadInstructions.Discriminator(name)
+		data := append(disc[:], 1, 2, 3, 4)
+
+		encoded, keys := launchpadTransaction(t, data)
+		result := mustParse(t, encoded, 0, &TransactionMeta{})
+		if len(result.Trade) != 0 || len(result.Create) != 0 {
+			t.Errorf("%s: expected nothing from truncated data, got %+v, %+v", name, result.Trade, result.Create)
+		}
+		if len(result.Diagnostics) != 1 || !errors.Is(result.Diagnostics[0], ErrMalformedData) {
+			t.Errorf("%s: expected a malformed data diagnostic, got %v", name, result.Diagnostics)
+		}
+
+		instruction := GeyserInstruction{ProgramID: RaydiumLaunchpadV1ProgramID, Accounts: keys[2:], Data: data}
+		result = &Transaction{}
+		if err := parse(instruction, 0, name, keys[0], result); !errors.Is(err, ErrMalformedData) || len(result.Trade) != 0 || len(result.Create) != 0 {
+			t.Errorf("%s: expected ErrMalformedData and nothing from the Geyser parser, got %v", name, err)
+		}
+	}
+}
+
+func TestUnknownSwapSizedRaydiumInstructionIsMalformed(t *testing.T) {
+	SetParseMode(LenientParsing)
+	defer SetParseMode(StrictParsing)
+
+	// No known layout places amounts at these offsets, so nothing is guessed from them
+	data := appendU64s([]byte{0xee, 0xee, 0xee, 0xee, 0xee, 0xee, 0xee, 0xee}, 1000, 900)
+	result := &Transaction{}
+	err := defaultParser().parseGenericRaydiumInstruction(solana.CompiledInstruction{Accounts: accountRange(0, 8), Data: data},
+		&solana.Message{AccountKeys: testKeys(8)}, 0, result, 0xeeeeeeeeeeeeeeee)
+	if !errors.Is(err, ErrMalformedData) || len(result.Trade) != 0 {
+		t.Errorf("Expected ErrMalformedData and no trade, got %v, %+v", err, result.Trade)
+	}
+}
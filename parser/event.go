@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"sort"
+)
+
+// EventKind identifies the operation an Event carries
+type EventKind string
+
+const (
+	EventCreate          EventKind = "create"
+	EventTrade           EventKind = "trade"
+	EventMigrate         EventKind = "migrate"
+	EventLiquidityAdd    EventKind = "liquidity_add"
+	EventLiquidityRemove EventKind = "liquidity_remove"
+)
+
+// Event is one operation of a transaction. Exactly the field matching Kind is set; it points into
+// the Create, Trade, Migrate, LiquidityAdds or LiquidityRemoves slice of the transaction.
+type Event struct {
+	Kind             EventKind
+	InstructionIndex int
+	InnerIndex       int // Position among the inner instructions of InstructionIndex, -1 for top-level
+
+	Create          *CreateInfo
+	Trade           *TradeInfo
+	Migrate         *Migration
+	LiquidityAdd    *LiquidityAdd
+	LiquidityRemove *LiquidityRemove
+}
+
+// eventCounts is how many operations of each kind a transaction held before an instruction was parsed
+type eventCounts struct {
+	creates, trades, migrations, adds, removes int
+}
+
+func countEvents(result *Transaction) eventCounts {
+	return eventCounts{
+		creates:    len(result.Create),
+		trades:     len(result.Trade),
+		migrations: len(result.Migrate),
+		adds:       len(result.LiquidityAdds),
+		removes:    len(result.LiquidityRemoves),
+	}
+}
+
+// setEventPositions records where in the instruction tree the operations appended since from were found
+func setEventPositions(result *Transaction, from eventCounts, index int, innerIndex int, stackHeight int) {
+	for i := from.creates; i < len(result.Create); i++ {
+		result.Create[i].InstructionIndex, result.Create[i].InnerIndex = index, innerIndex
+	}
+	for i := from.trades; i < len(result.Trade); i++ {
+		result.Trade[i].InstructionIndex, result.Trade[i].InnerIndex = index, innerIndex
+		result.Trade[i].StackHeight = stackHeight
+	}
+	for i := from.migrations; i < len(result.Migrate); i++ {
+		result.Migrate[i].InstructionIndex, result.Migrate[i].InnerIndex = index, innerIndex
+	}
+	for i := from.adds; i < len(result.LiquidityAdds); i++ {
+		result.LiquidityAdds[i].InstructionIndex, result.LiquidityAdds[i].InnerIndex = index, innerIndex
+	}
+	for i := from.removes; i < len(result.LiquidityRemoves); i++ {
+		result.LiquidityRemoves[i].InstructionIndex, result.LiquidityRemoves[i].InnerIndex = index, innerIndex
+	}
+}
+
+// buildEvents derives the buy and sell views of the trades and lists every operation in the order
+// its instruction ran. Call it once the operations are final.
+func (p *Parser) buildEvents(result *Transaction) {
+	p.deriveTradeViews(result)
+
+	events := make([]Event, 0, len(result.Create)+len(result.Trade)+len(result.Migrate)+
+		len(result.LiquidityAdds)+len(result.LiquidityRemoves))
+	for i := range result.Create {
+		create := &result.Create[i]
+		events = append(events, Event{Kind: EventCreate, InstructionIndex: create.InstructionIndex, InnerIndex: create.InnerIndex, Create: create})
+	}
+	for i := range result.Trade {
+		trade := &result.Trade[i]
+		events = append(events, Event{Kind: EventTrade, InstructionIndex: trade.InstructionIndex, InnerIndex: trade.InnerIndex, Trade: trade})
+	}
+	for i := range result.Migrate {
+		migration := &result.Migrate[i]
+		events = append(events, Event{Kind: EventMigrate, InstructionIndex: migration.InstructionIndex, InnerIndex: migration.InnerIndex, Migrate: migration})
+	}
+	for i := range result.LiquidityAdds {
+		add := &result.LiquidityAdds[i]
+		events = append(events, Event{Kind: EventLiquidityAdd, InstructionIndex: add.InstructionIndex, InnerIndex: add.InnerIndex, LiquidityAdd: add})
+	}
+	for i := range result.LiquidityRemoves {
+		remove := &result.LiquidityRemoves[i]
+		events = append(events, Event{Kind: EventLiquidityRemove, InstructionIndex: remove.InstructionIndex, InnerIndex: remove.InnerIndex, LiquidityRemove: remove})
+	}
+
+	// Top-level instructions (inner index -1) run before the instructions they invoke
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].InstructionIndex != events[j].InstructionIndex {
+			return events[i].InstructionIndex < events[j].InstructionIndex
+		}
+		return events[i].InnerIndex < events[j].InnerIndex
+	})
+	result.Events = events
+}
+
+// deriveTradeViews files every trade under the buys or the sells. Launchpad trades say which they
+// are; swaps are buys when a quote token was paid in.
+func (p *Parser) deriveTradeViews(result *Transaction) {
+	result.TradeBuys, result.TradeSells = []int{}, []int{}
+	result.SwapBuys, result.SwapSells = []SwapBuy{}, []SwapSell{}
+
+	for _, trade := range result.Trade {
+		// Exact-in trades are measured against their minimum out, exact-out trades against their maximum in
+		var slippage float64
+		if trade.MinAmountOut > 0 {
+			slippage = calculateSlippage(trade.AmountOut, trade.MinAmountOut)
+		} else if trade.MaxAmountIn > 0 {
+			slippage = calculateSlippage(trade.AmountIn, trade.MaxAmountIn)
+		}
+		isBuy := trade.TradeType == "buy" || (trade.TradeType != "sell" && p.isBaseCurrency(trade.TokenIn))
+		if isBuy {
+			result.TradeBuys = append(result.TradeBuys, trade.InstructionIndex)
+			result.SwapBuys = append(result.SwapBuys, SwapBuy{
+				TokenIn:         trade.TokenIn,
+				TokenOut:        trade.TokenOut,
+				AmountIn:        trade.AmountIn,
+				AmountOut:       trade.AmountOut,
+				MinAmountOut:    trade.MinAmountOut,
+				MaxAmountIn:     trade.MaxAmountIn,
+				Pool:            trade.Pool,
+				Buyer:           trade.Trader,
+				Slippage:        slippage,
+				InstructionName: trade.InstructionName,
+			})
+			continue
+		}
+
+		result.TradeSells = append(result.TradeSells, trade.InstructionIndex)
+		result.SwapSells = append(result.SwapSells, SwapSell{
+			TokenIn:         trade.TokenIn,
+			TokenOut:        trade.TokenOut,
+			AmountIn:        trade.AmountIn,
+			AmountOut:       trade.AmountOut,
+			MinAmountOut:    trade.MinAmountOut,
+			MaxAmountIn:     trade.MaxAmountIn,
+			Pool:            trade.Pool,
+			Seller:          trade.Trader,
+			Slippage:        slippage,
+			InstructionName: trade.InstructionName,
+		})
+	}
+}
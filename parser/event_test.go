@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLaunchpadBuyIsFiledAsSwapBuy(t *testing.T) {
+	encoded, _ := buildLaunchpadTradeTx(t, LaunchpadBuyExactIn, 200000000, 7000000000000)
+
+	result := mustParse(t, encoded, 0, &TransactionMeta{})
+
+	if len(result.TradeBuys) != 1 || len(result.SwapBuys) != 1 {
+		t.Fatalf("Expected 1 trade buy and 1 swap buy, got %d and %d", len(result.TradeBuys), len(result.SwapBuys))
+	}
+	if buy := result.SwapBuys[0]; buy.AmountIn != 200000000 || buy.MinAmountOut != 7000000000000 {
+		t.Errorf("Expected amount in 200000000 with minimum out 7000000000000, got %d and %d", buy.AmountIn, buy.MinAmountOut)
+	}
+	for _, issue := range ValidateTransaction(result) {
+		if strings.HasPrefix(issue, "Mismatch") {
+			t.Errorf("Unexpected validation issue: %s", issue)
+		}
+	}
+
+	if len(result.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(result.Events))
+	}
+	if event := result.Events[0]; event.Kind != EventTrade || event.InstructionIndex != 0 || event.InnerIndex != -1 || event.Trade != &result.Trade[0] {
+		t.Errorf("Expected the top-level trade of instruction 0, got %+v", event)
+	}
+}
+
+func TestEventsFollowInstructionOrder(t *testing.T) {
+	result := &Transaction{
+		Create:           []CreateInfo{{InstructionIndex: 1, InnerIndex: -1}},
+		Trade:            []TradeInfo{{InstructionIndex: 0, InnerIndex: 2, TradeType: "buy"}, {InstructionIndex: 0, InnerIndex: -1, TradeType: "sell"}},
+		Migrate:          []Migration{{InstructionIndex: 1, InnerIndex: 0}},
+		LiquidityRemoves: []LiquidityRemove{{InstructionIndex: 2, InnerIndex: -1}},
+	}
+	defaultParser().buildEvents(result)
+
+	want := []struct {
+		kind              EventKind
+		index, innerIndex int
+	}{
+		{EventTrade, 0, -1},
+		{EventTrade, 0, 2},
+		{EventCreate, 1, -1},
+		{EventMigrate, 1, 0},
+		{EventLiquidityRemove, 2, -1},
+	}
+	if len(result.Events) != len(want) {
+		t.Fatalf("Expected %d events, got %d", len(want), len(result.Events))
+	}
+	for i, w := range want {
+		event := result.Events[i]
+		if event.Kind != w.kind || event.InstructionIndex != w.index || event.InnerIndex != w.innerIndex {
+			t.Errorf("Event %d: expected %s at %d/%d, got %s at %d/%d",
+				i, w.kind, w.index, w.innerIndex, event.Kind, event.InstructionIndex, event.InnerIndex)
+		}
+	}
+
+	if len(result.TradeBuys) != 1 || len(result.TradeSells) != 1 || len(result.SwapBuys) != 1 || len(result.SwapSells) != 1 {
+		t.Errorf("Expected 1 buy and 1 sell in every view, got %d/%d trades and %d/%d swaps",
+			len(result.TradeBuys), len(result.TradeSells), len(result.SwapBuys), len(result.SwapSells))
+	}
+}
@@ -0,0 +1,297 @@
+package parser
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Launchpad event names as declared in the program IDL
+const (
+	LaunchpadTradeEventName      = "TradeEvent"
+	LaunchpadPoolCreateEventName = "PoolCreateEvent"
+)
+
+// LaunchpadTradeEvent is the TradeEvent the Launchpad program emits for every buy and sell
+type LaunchpadTradeEvent struct {
+	InstructionIndex int // Top-level instruction the event was emitted under
+	PoolState        solana.PublicKey
+	TotalBaseSell    uint64
+	VirtualBase      uint64
+	VirtualQuote     uint64
+	RealBaseBefore   uint64
+	RealQuoteBefore  uint64
+	RealBaseAfter    uint64
+	RealQuoteAfter   uint64
+	AmountIn         uint64
+	AmountOut        uint64
+	ProtocolFee      uint64
+	PlatformFee      uint64
+	CreatorFee       uint64
+	ShareFee         uint64
+	TradeDirection   string // "Buy" or "Sell"
+	PoolStatus       string // "Fund", "Migrate" or "Trade"
+	ExactIn          bool
+}
+
+// LaunchpadPoolCreateEvent is the PoolCreateEvent the Launchpad program emits when a token is launched
+type LaunchpadPoolCreateEvent struct {
+	InstructionIndex      int // Top-level instruction the event was emitted under
+	PoolState             solana.PublicKey
+	Creator               solana.PublicKey
+	Config                solana.PublicKey
+	Decimals              uint8
+	Name                  string
+	Symbol                string
+	URI                   string
+	Curve                 string // "Constant", "Fixed" or "Linear"
+	Supply                uint64
+	TotalBaseSell         uint64
+	TotalQuoteFundRaising uint64
+}
+
+// Size of a TradeEvent body emitted before creator fees were added to the program
+const legacyTradeEventSize = 32 + 12*8 + 3
+
+// launchpadEvents holds the events decoded from one transaction
+type launchpadEvents struct {
+	Trades  []LaunchpadTradeEvent
+	Creates []LaunchpadPoolCreateEvent
+
+	logger Logger
+	idl    *IDL // Launchpad IDL of the parser; nil if it has none
+}
+
+func (p *Parser) newLaunchpadEvents() *launchpadEvents {
+	idl, _ := p.idl(RaydiumLaunchpadV1ProgramID)
+	return &launchpadEvents{logger: p.logger, idl: idl}
+}
+
+// decodeLaunchpadEvent decodes an event (discriminator included) emitted under the given top-level instruction
+func (e *launchpadEvents) decodeLaunchpadEvent(data []byte, index int) {
+	idl := e.idl
+	if idl == nil || len(data) < 8 {
+		return
+	}
+
+	disc := Discriminator(data[:8])
+	if disc == AnchorDiscriminator("event", LaunchpadTradeEventName) && len(data)-8 == legacyTradeEventSize {
+		// Older events lack creator_fee, which sits between platform_fee and share_fee
+		offset := 8 + 32 + 11*8
+		data = append(append(append([]byte{}, data[:offset]...), make([]byte, 8)...), data[offset:]...)
+	}
+
+	name, values, err := idl.DecodeEvent(data)
+	if err != nil && disc == AnchorDiscriminator("event", LaunchpadPoolCreateEventName) {
+		// Older events end before amm_fee_on, whose first variant is the default
+		name, values, err = idl.DecodeEvent(append(append([]byte{}, data...), 0))
+	}
+	if err != nil {
+		e.logger.Printf("Failed to decode Launchpad event: %v", err)
+		return
+	}
+
+	switch name {
+	case LaunchpadTradeEventName:
+		e.Trades = append(e.Trades, LaunchpadTradeEvent{
+			InstructionIndex: index,
+			PoolState:        values.PublicKey("pool_state"),
+			TotalBaseSell:    values.Uint64("total_base_sell"),
+			VirtualBase:      values.Uint64("virtual_base"),
+			VirtualQuote:     values.Uint64("virtual_quote"),
+			RealBaseBefore:   values.Uint64("real_base_before"),
+			RealQuoteBefore:  values.Uint64("real_quote_before"),
+			RealBaseAfter:    values.Uint64("real_base_after"),
+			RealQuoteAfter:   values.Uint64("real_quote_after"),
+			AmountIn:         values.Uint64("amount_in"),
+			AmountOut:        values.Uint64("amount_out"),
+			ProtocolFee:      values.Uint64("protocol_fee"),
+			PlatformFee:      values.Uint64("platform_fee"),
+			CreatorFee:       values.Uint64("creator_fee"),
+			ShareFee:         values.Uint64("share_fee"),
+			TradeDirection:   values.Enum("trade_direction").Variant,
+			PoolStatus:       values.Enum("pool_status").Variant,
+			ExactIn:          values.Bool("exact_in"),
+		})
+	case LaunchpadPoolCreateEventName:
+		mintParams := values.Struct("base_mint_param")
+		curve := values.Enum("curve_param")
+		curveData := curve.Fields.Struct("data")
+		e.Creates = append(e.Creates, LaunchpadPoolCreateEvent{
+			InstructionIndex:      index,
+			PoolState:             values.PublicKey("pool_state"),
+			Creator:               values.PublicKey("creator"),
+			Config:                values.PublicKey("config"),
+			Decimals:              mintParams.Uint8("decimals"),
+			Name:                  mintParams.String("name"),
+			Symbol:                mintParams.String("symbol"),
+			URI:                   mintParams.String("uri"),
+			Curve:                 curve.Variant,
+			Supply:                curveData.Uint64("supply"),
+			TotalBaseSell:         curveData.Uint64("total_base_sell"),
+			TotalQuoteFundRaising: curveData.Uint64("total_quote_fund_raising"),
+		})
+	}
+}
+
+// addCPIEvent decodes an emit_cpi inner instruction of the Launchpad program
+func (e *launchpadEvents) addCPIEvent(programID solana.PublicKey, data []byte, index int) bool {
+	if !programID.Equals(RaydiumLaunchpadV1ProgramID) || !isAnchorEventCPI(data) {
+		return false
+	}
+	e.decodeLaunchpadEvent(data[8:], index)
+	return true
+}
+
+// addLogEvents decodes the "Program data:" lines the Launchpad program logged. Top-level instructions
+// whose events were already taken from emit_cpi instructions are skipped.
+func (e *launchpadEvents) addLogEvents(logs []string, skip map[int]bool) {
+	var stack []string
+	index := -1
+
+	for _, line := range logs {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] != "Program" {
+			continue
+		}
+
+		switch {
+		case fields[1] == "data:":
+			if len(stack) == 0 || stack[len(stack)-1] != RaydiumLaunchpadV1ProgramID.String() || skip[index] {
+				continue
+			}
+			data, err := base64.StdEncoding.DecodeString(fields[2])
+			if err != nil {
+				continue
+			}
+			e.decodeLaunchpadEvent(data, index)
+		case !isProgramAddress(fields[1]):
+			// "Program log: ..." and other lines programs print themselves
+			continue
+		case fields[2] == "invoke" && len(fields) == 4 && isInvokeDepth(fields[3]):
+			if fields[3] == "[1]" {
+				index++
+				stack = stack[:0]
+			}
+			stack = append(stack, fields[1])
+		case fields[2] == "success" && len(fields) == 3, fields[2] == "failed:":
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+// isProgramAddress reports whether a log field is a base58 program address
+func isProgramAddress(field string) bool {
+	_, err := solana.PublicKeyFromBase58(field)
+	return err == nil
+}
+
+// isInvokeDepth reports whether a log field is the "[n]" stack depth of an invoke line
+func isInvokeDepth(field string) bool {
+	if len(field) < 3 || field[0] != '[' || field[len(field)-1] != ']' {
+		return false
+	}
+	_, err := strconv.Atoi(field[1 : len(field)-1])
+	return err == nil
+}
+
+// collectLaunchpadEvents decodes the Launchpad events of a standard transaction, preferring
+// emit_cpi inner instructions over (possibly truncated) log lines
+func (p *Parser) collectLaunchpadEvents(message *solana.Message, meta *TransactionMeta) *launchpadEvents {
+	events := p.newLaunchpadEvents()
+	if meta == nil {
+		return events
+	}
+
+	fromCPI := make(map[int]bool)
+	for _, set := range meta.InnerInstructions {
+		for _, inner := range set.Instructions {
+			programIndex := int(inner.Instruction.ProgramIDIndex)
+			if programIndex >= len(message.AccountKeys) {
+				continue
+			}
+			if events.addCPIEvent(message.AccountKeys[programIndex], inner.Instruction.Data, set.Index) {
+				fromCPI[set.Index] = true
+			}
+		}
+	}
+
+	events.addLogEvents(meta.LogMessages, fromCPI)
+	return events
+}
+
+// collectGeyserLaunchpadEvents decodes the Launchpad events of a Geyser transaction
+func (p *Parser) collectGeyserLaunchpadEvents(geyserTx *GeyserTransaction) *launchpadEvents {
+	events := p.newLaunchpadEvents()
+
+	fromCPI := make(map[int]bool)
+	for _, set := range geyserTx.InnerInstructions {
+		for _, inner := range set.Instructions {
+			if events.addCPIEvent(inner.ProgramID, inner.Data, set.Index) {
+				fromCPI[set.Index] = true
+			}
+		}
+	}
+
+	if geyserTx.Meta != nil {
+		events.addLogEvents(geyserTx.Meta.LogMessages, fromCPI)
+	}
+	return events
+}
+
+// applyLaunchpadEvents records the decoded events on the transaction and fills the amounts
+// of the Launchpad trades and creates they belong to
+func applyLaunchpadEvents(result *Transaction, events *launchpadEvents) {
+	result.TradeEvents = append(result.TradeEvents, events.Trades...)
+	result.PoolCreateEvents = append(result.PoolCreateEvents, events.Creates...)
+
+	used := make([]bool, len(events.Trades))
+	for i := range result.Trade {
+		trade := &result.Trade[i]
+		if !isLaunchpadTrade(trade.InstructionName) {
+			continue
+		}
+
+		for j, event := range events.Trades {
+			if used[j] || event.InstructionIndex != trade.InstructionIndex ||
+				(!trade.Pool.IsZero() && !event.PoolState.Equals(trade.Pool)) {
+				continue
+			}
+			used[j] = true
+
+			trade.AmountIn = event.AmountIn
+			trade.AmountOut = event.AmountOut
+			break
+		}
+	}
+
+	for i := range result.Create {
+		create := &result.Create[i]
+		for _, event := range events.Creates {
+			if !event.PoolState.Equals(create.PoolAddress) {
+				continue
+			}
+			if create.TokenSymbol == "" || create.TokenSymbol == "UNKNOWN" {
+				create.TokenSymbol = event.Symbol
+			}
+			if create.Creator.IsZero() {
+				create.Creator = event.Creator
+			}
+			create.TokenDecimals = event.Decimals
+			create.Amount = event.Supply
+			break
+		}
+	}
+}
+
+func isLaunchpadTrade(name string) bool {
+	switch name {
+	case LaunchpadBuyExactIn, LaunchpadBuyExactOut, LaunchpadSellExactIn, LaunchpadSellExactOut:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// encodeTradeEvent builds a TradeEvent with the discriminator, optionally in the layout without creator_fee
+func encodeTradeEvent(pool solana.PublicKey, amountIn, amountOut uint64, direction byte, withCreatorFee bool) []byte {
+	disc := AnchorDiscriminator("event", LaunchpadTradeEventName)
+	data := append([]byte{}, disc[:]...)
+	data = append(data, pool[:]...)
+	fields := []uint64{793100000000000, 1073025605596382, 30000852951, 100, 0, 200, amountIn, amountIn, amountOut, 2500, 1000}
+	if withCreatorFee {
+		fields = append(fields, 500)
+	}
+	fields = append(fields, 0) // share_fee
+	for _, f := range fields {
+		data = binary.LittleEndian.AppendUint64(data, f)
+	}
+	return append(data, direction, 0, 1)
+}
+
+func TestTradeEventFromLogs(t *testing.T) {
+	encoded, keys := buildLaunchpadTradeTx(t, LaunchpadSellExactIn, 1000000000, 45000000)
+	pool := keys[5] // pool_state is the 5th instruction account
+
+	event := encodeTradeEvent(pool, 1000000000, 50000000, 1, true)
+	launchpad := RaydiumLaunchpadV1ProgramID.String()
+	meta := &TransactionMeta{
+		LogMessages: []string{
+			"Program " + launchpad + " invoke [1]",
+			"Program log: Instruction: SellExactIn",
+			"Program data: " + base64.StdEncoding.EncodeToString(event),
+			"Program " + launchpad + " consumed 52000 of 200000 compute units",
+			"Program " + launchpad + " success",
+		},
+	}
+
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.TradeEvents) != 1 || result.TradeEvents[0].CreatorFee != 500 || result.TradeEvents[0].TradeDirection != "Sell" {
+		t.Fatalf("Unexpected trade events: %+v", result.TradeEvents)
+	}
+	if len(result.Trade) != 1 || result.Trade[0].AmountOut != 50000000 {
+		t.Fatalf("Expected trade amount out 50000000, got %+v", result.Trade)
+	}
+	if len(result.SwapSells) != 1 {
+		t.Fatalf("Expected 1 swap sell, got %d", len(result.SwapSells))
+	}
+	// 5000000 over the minimum out of 45000000
+	if sell := result.SwapSells[0]; sell.AmountOut != 50000000 || sell.Slippage < 0.1110 || sell.Slippage > 0.1112 {
+		t.Errorf("Expected amount out 50000000 with 11.1%% slippage, got %d and %f", sell.AmountOut, sell.Slippage)
+	}
+}
+
+func TestTradeEventLogsIgnoreProgramOutput(t *testing.T) {
+	encoded, keys := buildLaunchpadTradeTx(t, LaunchpadSellExactIn, 1000000000, 45000000)
+	event := encodeTradeEvent(keys[5], 1000000000, 50000000, 1, true)
+	launchpad := RaydiumLaunchpadV1ProgramID.String()
+
+	// Program output that reads like invoke and success lines must not move the invocation stack
+	meta := &TransactionMeta{
+		LogMessages: []string{
+			"Program " + launchpad + " invoke [1]",
+			"Program log: invoke [1]",
+			"Program log: success",
+			"Program data: " + base64.StdEncoding.EncodeToString(event),
+			"Program " + launchpad + " success",
+		},
+	}
+
+	result := mustParse(t, encoded, 0, meta)
+	if len(result.TradeEvents) != 1 || result.TradeEvents[0].InstructionIndex != 0 {
+		t.Fatalf("Expected the trade event of instruction 0, got %+v", result.TradeEvents)
+	}
+	if len(result.Trade) != 1 || result.Trade[0].AmountOut != 50000000 {
+		t.Errorf("Expected trade amount out 50000000, got %+v", result.Trade)
+	}
+}
+
+func TestLegacyTradeEventFromCPI(t *testing.T) {
+	encoded, keys := buildLaunchpadTradeTx(t, LaunchpadBuyExactIn, 200000000, 1)
+	pool := keys[5]
+
+	event := encodeTradeEvent(pool, 198000000, 7100000000000, 0, false)
+	if len(event) != 8+legacyTradeEventSize {
+		t.Fatalf("Expected legacy event size %d, got %d", 8+legacyTradeEventSize, len(event))
+	}
+
+	meta := &TransactionMeta{
+		InnerInstructions: []InnerInstructionSet{{
+			Index: 0,
+			Instructions: []InnerInstruction{{
+				Instruction: solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: []uint16{14}, Data: append(AnchorEventCPITag[:], event...)},
+				StackHeight: 2,
+			}},
+		}},
+	}
+
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.TradeEvents) != 1 || result.TradeEvents[0].CreatorFee != 0 || result.TradeEvents[0].ShareFee != 0 {
+		t.Fatalf("Unexpected legacy trade event: %+v", result.TradeEvents)
+	}
+	if result.TradeEvents[0].TradeDirection != "Buy" || !result.TradeEvents[0].ExactIn {
+		t.Errorf("Expected exact-in buy, got %+v", result.TradeEvents[0])
+	}
+	if len(result.Trade) != 1 || result.Trade[0].AmountIn != 198000000 || result.Trade[0].AmountOut != 7100000000000 {
+		t.Errorf("Expected trade amounts from the event, got %+v", result.Trade)
+	}
+}
+
+func TestLaunchpadSlippageAgainstLimits(t *testing.T) {
+	tests := []struct {
+		name                string
+		amount, limit       uint64
+		amountIn, amountOut uint64
+		direction           byte
+		minOut, maxIn       uint64
+		slippage            float64
+	}{
+		// 7.5 tokens minimum out, 8 received
+		{LaunchpadBuyExactIn, 200000000, 7500000000, 200000000, 8000000000, 0, 7500000000, 0, 0.0667},
+		// 8 tokens out for at most 0.25 SOL, 0.2 paid
+		{LaunchpadBuyExactOut, 8000000000, 250000000, 200000000, 8000000000, 0, 0, 250000000, 0.2},
+		// 0.05 SOL out for at most 1.25 tokens, 1 paid
+		{LaunchpadSellExactOut, 50000000, 1250000000, 1000000000, 50000000, 1, 0, 1250000000, 0.2},
+	}
+	for _, tt := range tests {
+		encoded, keys := buildLaunchpadTradeTx(t, tt.name, tt.amount, tt.limit)
+		event := encodeTradeEvent(keys[5], tt.amountIn, tt.amountOut, tt.direction, true)
+		launchpad := RaydiumLaunchpadV1ProgramID.String()
+		meta := &TransactionMeta{LogMessages: []string{
+			"Program " + launchpad + " invoke [1]",
+			"Program data: " + base64.StdEncoding.EncodeToString(event),
+			"Program " + launchpad + " success",
+		}}
+		result := mustParse(t, encoded, 0, meta)
+
+		if len(result.Trade) != 1 {
+			t.Fatalf("%s: expected 1 trade, got %d", tt.name, len(result.Trade))
+		}
+		trade := result.Trade[0]
+		if trade.MinAmountOut != tt.minOut || trade.MaxAmountIn != tt.maxIn {
+			t.Errorf("%s: expected limits %d/%d, got %d/%d", tt.name, tt.minOut, tt.maxIn, trade.MinAmountOut, trade.MaxAmountIn)
+		}
+		var slippage float64
+		if len(result.SwapBuys) == 1 {
+			slippage = result.SwapBuys[0].Slippage
+		} else if len(result.SwapSells) == 1 {
+			slippage = result.SwapSells[0].Slippage
+		}
+		if slippage < tt.slippage-0.0001 || slippage > tt.slippage+0.0001 {
+			t.Errorf("%s: expected slippage %.4f, got %.4f", tt.name, tt.slippage, slippage)
+		}
+	}
+}
@@ -0,0 +1,522 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gagliardetto/solana-go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of the Yellowstone gRPC messages (geyser.proto and solana-storage.proto)
+const (
+	subscribeUpdateTransactionField = 4  // SubscribeUpdate.transaction
+	subscribeUpdateCreatedAtField   = 11 // SubscribeUpdate.created_at
+	timestampSecondsField           = 1  // google.protobuf.Timestamp.seconds
+
+	updateTransactionInfoField = 1 // SubscribeUpdateTransaction.transaction
+	updateTransactionSlotField = 2 // SubscribeUpdateTransaction.slot
+
+	transactionInfoSignatureField   = 1
+	transactionInfoIsVoteField      = 2
+	transactionInfoTransactionField = 3
+	transactionInfoMetaField        = 4
+
+	transactionSignaturesField = 1
+	transactionMessageField    = 2
+
+	messageHeaderField       = 1
+	messageAccountKeysField  = 2
+	messageInstructionsField = 4
+
+	metaErrField                     = 1
+	metaFeeField                     = 2
+	metaPreBalancesField             = 3
+	metaPostBalancesField            = 4
+	metaInnerInstructionsField       = 5
+	metaLogMessagesField             = 6
+	metaPreTokenBalancesField        = 7
+	metaPostTokenBalancesField       = 8
+	metaLoadedWritableAddressesField = 12
+	metaLoadedReadonlyAddressesField = 13
+	metaComputeUnitsConsumedField    = 16
+)
+
+// protoField is one field of an encoded protobuf message. Varint and fixed-width values are
+// held in Value, length-delimited ones in Bytes.
+type protoField struct {
+	Number protowire.Number
+	Type   protowire.Type
+	Value  uint64
+	Bytes  []byte
+}
+
+// walkProto calls fn for every field of an encoded protobuf message, in wire order
+func walkProto(b []byte, fn func(field protoField) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		field := protoField{Number: num, Type: typ}
+		switch typ {
+		case protowire.VarintType:
+			field.Value, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			field.Value, n = protowire.ConsumeFixed64(b)
+		case protowire.Fixed32Type:
+			var v uint32
+			v, n = protowire.ConsumeFixed32(b)
+			field.Value = uint64(v)
+		case protowire.BytesType:
+			field.Bytes, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if err := fn(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expect fails when a field doesn't have the wire type of its schema
+func (f protoField) expect(typ protowire.Type) error {
+	if f.Type != typ {
+		return fmt.Errorf("field %d: unexpected wire type %d", f.Number, f.Type)
+	}
+	return nil
+}
+
+// appendUint64s appends a repeated uint64 field, packed or not
+func (f protoField) appendUint64s(values []uint64) ([]uint64, error) {
+	if f.Type == protowire.VarintType {
+		return append(values, f.Value), nil
+	}
+	if err := f.expect(protowire.BytesType); err != nil {
+		return nil, err
+	}
+	for b := f.Bytes; len(b) > 0; {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		values = append(values, v)
+		b = b[n:]
+	}
+	return values, nil
+}
+
+// publicKey reads a 32-byte address field
+func (f protoField) publicKey() (solana.PublicKey, error) {
+	if err := f.expect(protowire.BytesType); err != nil {
+		return solana.PublicKey{}, err
+	}
+	if len(f.Bytes) != solana.PublicKeyLength {
+		return solana.PublicKey{}, fmt.Errorf("field %d: invalid public key length %d", f.Number, len(f.Bytes))
+	}
+	return solana.PublicKeyFromBytes(f.Bytes), nil
+}
+
+// DecodeGeyserTransaction decodes a Yellowstone gRPC transaction update: either a
+// SubscribeUpdateTransaction or the SubscribeUpdate that wraps it. Address lookup tables
+// are resolved from the meta's loaded addresses.
+func DecodeGeyserTransaction(data []byte) (*GeyserTransaction, error) {
+	var update []byte
+	var createdAt int64
+	err := walkProto(data, func(field protoField) error {
+		if field.Type != protowire.BytesType {
+			return nil
+		}
+		switch field.Number {
+		case subscribeUpdateTransactionField:
+			update = field.Bytes
+		case subscribeUpdateCreatedAtField:
+			return walkProto(field.Bytes, func(timestamp protoField) error {
+				if timestamp.Number == timestampSecondsField {
+					createdAt = int64(timestamp.Value)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Geyser update: %w", err)
+	}
+	if update == nil {
+		update = data // Not wrapped in a SubscribeUpdate
+	}
+
+	var info []byte
+	var slot uint64
+	err = walkProto(update, func(field protoField) error {
+		switch field.Number {
+		case updateTransactionInfoField:
+			if err := field.expect(protowire.BytesType); err != nil {
+				return err
+			}
+			info = field.Bytes
+		case updateTransactionSlotField:
+			if err := field.expect(protowire.VarintType); err != nil {
+				return err
+			}
+			slot = field.Value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction update: %w", err)
+	}
+	if info == nil {
+		return nil, fmt.Errorf("update carries no transaction")
+	}
+
+	geyserTx, err := decodeGeyserTransactionInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	geyserTx.Slot = slot
+	geyserTx.ReceivedAt = createdAt
+	return geyserTx, nil
+}
+
+// decodeGeyserTransactionInfo decodes a SubscribeUpdateTransactionInfo
+func decodeGeyserTransactionInfo(data []byte) (*GeyserTransaction, error) {
+	var signature, transaction, metaBytes []byte
+	var isVote bool
+	err := walkProto(data, func(field protoField) error {
+		switch field.Number {
+		case transactionInfoSignatureField:
+			signature = field.Bytes
+		case transactionInfoIsVoteField:
+			isVote = field.Value != 0
+		case transactionInfoTransactionField:
+			transaction = field.Bytes
+		case transactionInfoMetaField:
+			metaBytes = field.Bytes
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction info: %w", err)
+	}
+	if transaction == nil {
+		return nil, fmt.Errorf("transaction info carries no transaction")
+	}
+
+	message, signatures, err := decodeGeyserMessage(transaction)
+	if err != nil {
+		return nil, err
+	}
+	if signature == nil && len(signatures) > 0 {
+		signature = signatures[0]
+	}
+	if len(signature) != solana.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+
+	meta := &TransactionMeta{}
+	if metaBytes != nil {
+		if meta, err = decodeGeyserMeta(metaBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	// Lookup table accounts follow the static keys: writable first, then read-only
+	accountKeys := append([]solana.PublicKey{}, message.AccountKeys...)
+	accountKeys = append(accountKeys, meta.LoadedAddresses.Writable...)
+	accountKeys = append(accountKeys, meta.LoadedAddresses.ReadOnly...)
+
+	accounts := geyserAccounts{
+		keys:     accountKeys,
+		signer:   make([]bool, len(accountKeys)),
+		writable: make([]bool, len(accountKeys)),
+	}
+	for i := range accountKeys {
+		accounts.signer[i], accounts.writable[i] = accountFlags(message.Header, len(message.AccountKeys), len(meta.LoadedAddresses.Writable), i)
+	}
+
+	geyserTx := &GeyserTransaction{
+		Signature:   solana.SignatureFromBytes(signature),
+		IsVote:      isVote,
+		AccountKeys: accountKeys,
+		Meta:        meta,
+	}
+	if metaBytes == nil {
+		geyserTx.Meta = nil // No meta, so the execution result is unknown
+	}
+
+	for i, instruction := range message.Instructions {
+		resolved, err := accounts.resolve(instruction, 0)
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: %w", i, err)
+		}
+		geyserTx.Instructions = append(geyserTx.Instructions, resolved)
+	}
+
+	for _, set := range meta.InnerInstructions {
+		innerSet := GeyserInnerInstruction{Index: set.Index}
+		for j, inner := range set.Instructions {
+			resolved, err := accounts.resolve(inner.Instruction, inner.StackHeight)
+			if err != nil {
+				return nil, fmt.Errorf("inner instruction %d.%d: %w", set.Index, j, err)
+			}
+			innerSet.Instructions = append(innerSet.Instructions, resolved)
+		}
+		geyserTx.InnerInstructions = append(geyserTx.InnerInstructions, innerSet)
+	}
+
+	return geyserTx, nil
+}
+
+// geyserAccounts holds the full account list of a Geyser transaction with the signer and writable status of each account
+type geyserAccounts struct {
+	keys     []solana.PublicKey
+	signer   []bool
+	writable []bool
+}
+
+// resolve replaces the account indexes of a compiled instruction with the accounts themselves
+func (a geyserAccounts) resolve(instruction solana.CompiledInstruction, stackHeight int) (GeyserInstruction, error) {
+	if int(instruction.ProgramIDIndex) >= len(a.keys) {
+		return GeyserInstruction{}, fmt.Errorf("%w: program index %d of %d accounts", ErrAccountIndexOutOfRange, instruction.ProgramIDIndex, len(a.keys))
+	}
+
+	resolved := GeyserInstruction{
+		ProgramID:   a.keys[instruction.ProgramIDIndex],
+		Data:        instruction.Data,
+		StackHeight: stackHeight,
+	}
+	for _, accountIndex := range instruction.Accounts {
+		if int(accountIndex) >= len(a.keys) {
+			return GeyserInstruction{}, fmt.Errorf("%w: %d of %d accounts", ErrAccountIndexOutOfRange, accountIndex, len(a.keys))
+		}
+		resolved.Accounts = append(resolved.Accounts, a.keys[accountIndex])
+		resolved.IsSigner = append(resolved.IsSigner, a.signer[accountIndex])
+		resolved.IsWritable = append(resolved.IsWritable, a.writable[accountIndex])
+	}
+	return resolved, nil
+}
+
+// decodeGeyserMessage decodes a solana.storage Transaction into its message and signatures
+func decodeGeyserMessage(data []byte) (*solana.Message, [][]byte, error) {
+	var signatures [][]byte
+	var messageBytes []byte
+	err := walkProto(data, func(field protoField) error {
+		switch field.Number {
+		case transactionSignaturesField:
+			if err := field.expect(protowire.BytesType); err != nil {
+				return err
+			}
+			signatures = append(signatures, field.Bytes)
+		case transactionMessageField:
+			if err := field.expect(protowire.BytesType); err != nil {
+				return err
+			}
+			messageBytes = field.Bytes
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	if messageBytes == nil {
+		return nil, nil, fmt.Errorf("transaction carries no message")
+	}
+
+	message := &solana.Message{}
+	err = walkProto(messageBytes, func(field protoField) error {
+		switch field.Number {
+		case messageHeaderField:
+			return walkProto(field.Bytes, func(header protoField) error {
+				switch header.Number {
+				case 1:
+					message.Header.NumRequiredSignatures = uint8(header.Value)
+				case 2:
+					message.Header.NumReadonlySignedAccounts = uint8(header.Value)
+				case 3:
+					message.Header.NumReadonlyUnsignedAccounts = uint8(header.Value)
+				}
+				return nil
+			})
+		case messageAccountKeysField:
+			key, err := field.publicKey()
+			if err != nil {
+				return err
+			}
+			message.AccountKeys = append(message.AccountKeys, key)
+		case messageInstructionsField:
+			instruction, _, err := decodeGeyserInstruction(field.Bytes)
+			if err != nil {
+				return err
+			}
+			message.Instructions = append(message.Instructions, instruction)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode message: %w", err)
+	}
+	if len(message.AccountKeys) == 0 {
+		return nil, nil, fmt.Errorf("message carries no account keys")
+	}
+
+	return message, signatures, nil
+}
+
+// decodeGeyserInstruction decodes a CompiledInstruction or InnerInstruction; the stack height is 0 when absent
+func decodeGeyserInstruction(data []byte) (solana.CompiledInstruction, int, error) {
+	var instruction solana.CompiledInstruction
+	var stackHeight int
+	err := walkProto(data, func(field protoField) error {
+		switch field.Number {
+		case 1:
+			instruction.ProgramIDIndex = uint16(field.Value)
+		case 2:
+			if err := field.expect(protowire.BytesType); err != nil {
+				return err
+			}
+			for _, accountIndex := range field.Bytes {
+				instruction.Accounts = append(instruction.Accounts, uint16(accountIndex))
+			}
+		case 3:
+			if err := field.expect(protowire.BytesType); err != nil {
+				return err
+			}
+			instruction.Data = field.Bytes
+		case 4:
+			stackHeight = int(field.Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return solana.CompiledInstruction{}, 0, fmt.Errorf("failed to decode instruction: %w", err)
+	}
+	return instruction, stackHeight, nil
+}
+
+// decodeGeyserMeta decodes a solana.storage TransactionStatusMeta
+func decodeGeyserMeta(data []byte) (*TransactionMeta, error) {
+	meta := &TransactionMeta{}
+	err := walkProto(data, func(field protoField) error {
+		var err error
+		switch field.Number {
+		case metaErrField:
+			err = walkProto(field.Bytes, func(txErr protoField) error {
+				if txErr.Number == 1 {
+					meta.Err = transactionErrorFromBincode(txErr.Bytes)
+				}
+				return nil
+			})
+		case metaFeeField:
+			meta.Fee = field.Value
+		case metaComputeUnitsConsumedField:
+			meta.ComputeUnitsConsumed = field.Value
+		case metaPreBalancesField:
+			meta.PreBalances, err = field.appendUint64s(meta.PreBalances)
+		case metaPostBalancesField:
+			meta.PostBalances, err = field.appendUint64s(meta.PostBalances)
+		case metaInnerInstructionsField:
+			var set InnerInstructionSet
+			set, err = decodeGeyserInnerInstructions(field.Bytes)
+			meta.InnerInstructions = append(meta.InnerInstructions, set)
+		case metaLogMessagesField:
+			meta.LogMessages = append(meta.LogMessages, string(field.Bytes))
+		case metaPreTokenBalancesField:
+			var balance TokenBalance
+			balance, err = decodeGeyserTokenBalance(field.Bytes)
+			meta.PreTokenBalances = append(meta.PreTokenBalances, balance)
+		case metaPostTokenBalancesField:
+			var balance TokenBalance
+			balance, err = decodeGeyserTokenBalance(field.Bytes)
+			meta.PostTokenBalances = append(meta.PostTokenBalances, balance)
+		case metaLoadedWritableAddressesField:
+			var key solana.PublicKey
+			key, err = field.publicKey()
+			meta.LoadedAddresses.Writable = append(meta.LoadedAddresses.Writable, key)
+		case metaLoadedReadonlyAddressesField:
+			var key solana.PublicKey
+			key, err = field.publicKey()
+			meta.LoadedAddresses.ReadOnly = append(meta.LoadedAddresses.ReadOnly, key)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction meta: %w", err)
+	}
+	return meta, nil
+}
+
+// decodeGeyserInnerInstructions decodes the InnerInstructions of one top-level instruction
+func decodeGeyserInnerInstructions(data []byte) (InnerInstructionSet, error) {
+	var set InnerInstructionSet
+	err := walkProto(data, func(field protoField) error {
+		switch field.Number {
+		case 1:
+			set.Index = int(field.Value)
+		case 2:
+			instruction, stackHeight, err := decodeGeyserInstruction(field.Bytes)
+			if err != nil {
+				return err
+			}
+			set.Instructions = append(set.Instructions, InnerInstruction{Instruction: instruction, StackHeight: stackHeight})
+		}
+		return nil
+	})
+	return set, err
+}
+
+// decodeGeyserTokenBalance decodes a TokenBalance; mint and owner are base58 strings on the wire
+func decodeGeyserTokenBalance(data []byte) (TokenBalance, error) {
+	var balance TokenBalance
+	err := walkProto(data, func(field protoField) error {
+		var err error
+		switch field.Number {
+		case 1:
+			balance.AccountIndex = int(field.Value)
+		case 2:
+			balance.Mint, err = solana.PublicKeyFromBase58(string(field.Bytes))
+		case 3:
+			err = walkProto(field.Bytes, func(amount protoField) error {
+				switch amount.Number {
+				case 2:
+					balance.Decimals = uint8(amount.Value)
+				case 3:
+					balance.Amount, _ = strconv.ParseUint(string(amount.Bytes), 10, 64)
+				}
+				return nil
+			})
+		case 4:
+			if len(field.Bytes) > 0 {
+				balance.Owner, err = solana.PublicKeyFromBase58(string(field.Bytes))
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return TokenBalance{}, fmt.Errorf("failed to decode token balance: %w", err)
+	}
+	return balance, nil
+}
+
+// ParseGeyserTransaction parses a Yellowstone update with the default parser
+func ParseGeyserTransaction(data []byte) (*Transaction, error) {
+	return defaultParser().ParseGeyserTransaction(data)
+}
+
+// ParseGeyserTransaction parses a Yellowstone SubscribeUpdate or SubscribeUpdateTransaction message
+func (p *Parser) ParseGeyserTransaction(data []byte) (*Transaction, error) {
+	geyserTx, err := DecodeGeyserTransaction(data)
+	if err != nil {
+		return nil, err
+	}
+	return p.parseGeyserFormatTransaction(geyserTx)
+}
@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendProtoBytes(b []byte, num protowire.Number, value []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, value)
+}
+
+func appendProtoVarint(b []byte, num protowire.Number, value uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, value)
+}
+
+func encodeProtoInstruction(programIndex uint64, accounts []byte, data []byte, stackHeight uint64) []byte {
+	b := appendProtoVarint(nil, 1, programIndex)
+	b = appendProtoBytes(b, 2, accounts)
+	b = appendProtoBytes(b, 3, data)
+	if stackHeight > 0 {
+		b = appendProtoVarint(b, 4, stackHeight)
+	}
+	return b
+}
+
+func encodeProtoTokenBalance(accountIndex uint64, mint, owner solana.PublicKey, amount string) []byte {
+	uiAmount := appendProtoVarint(nil, 2, 6)
+	uiAmount = appendProtoBytes(uiAmount, 3, []byte(amount))
+
+	b := appendProtoVarint(nil, 1, accountIndex)
+	b = appendProtoBytes(b, 2, []byte(mint.String()))
+	b = appendProtoBytes(b, 3, uiAmount)
+	return appendProtoBytes(b, 4, []byte(owner.String()))
+}
+
+func TestParseGeyserV0LaunchpadBuy(t *testing.T) {
+	// Static keys: payer, program; the 14 remaining buy accounts come from a lookup table
+	payer := solana.NewWallet().PublicKey()
+	static := []solana.PublicKey{payer, RaydiumLaunchpadV1ProgramID}
+	var loaded []solana.PublicKey
+	for len(loaded) < 14 {
+		loaded = append(loaded, solana.NewWallet().PublicKey())
+	}
+	pool, baseMint, quoteMint := loaded[3], loaded[8], loaded[9]
+
+	disc, _ := LaunchpadInstructions.Discriminator(LaunchpadBuyExactIn)
+	data := append([]byte{}, disc[:]...)
+	data = binary.LittleEndian.AppendUint64(data, 200000000)
+	data = binary.LittleEndian.AppendUint64(data, 1)
+	data = binary.LittleEndian.AppendUint64(data, 0)
+
+	accounts := []byte{0}
+	for i := byte(2); i < 16; i++ {
+		accounts = append(accounts, i)
+	}
+
+	var message []byte
+	header := appendProtoVarint(nil, 1, 1)
+	header = appendProtoVarint(header, 3, 1)
+	message = appendProtoBytes(message, messageHeaderField, header)
+	for _, key := range static {
+		message = appendProtoBytes(message, messageAccountKeysField, key[:])
+	}
+	message = appendProtoBytes(message, 3, make([]byte, 32))
+	message = appendProtoBytes(message, messageInstructionsField, encodeProtoInstruction(1, accounts, data, 0))
+	message = appendProtoVarint(message, 5, 1)
+
+	signature := solana.Signature{1, 2, 3}
+	transaction := appendProtoBytes(nil, transactionSignaturesField, signature[:])
+	transaction = appendProtoBytes(transaction, transactionMessageField, message)
+
+	event := encodeTradeEvent(pool, 200000000, 7100000000000, 0, true)
+	inner := appendProtoVarint(nil, 1, 0)
+	inner = appendProtoBytes(inner, 2, encodeProtoInstruction(1, []byte{14}, append(AnchorEventCPITag[:], event...), 2))
+
+	meta := appendProtoVarint(nil, metaFeeField, 5000)
+	meta = appendProtoBytes(meta, metaPreBalancesField, protowire.AppendVarint(protowire.AppendVarint(nil, 1000000000), 1))
+	meta = appendProtoBytes(meta, metaPostBalancesField, protowire.AppendVarint(protowire.AppendVarint(nil, 799995000), 1))
+	meta = appendProtoBytes(meta, metaInnerInstructionsField, inner)
+	meta = appendProtoBytes(meta, metaLogMessagesField, []byte("Program "+RaydiumLaunchpadV1ProgramID.String()+" invoke [1]"))
+	meta = appendProtoBytes(meta, metaPostTokenBalancesField, encodeProtoTokenBalance(6, baseMint, payer, "7100000000000"))
+	meta = appendProtoBytes(meta, metaPreTokenBalancesField, encodeProtoTokenBalance(7, quoteMint, payer, "500000000"))
+	for _, key := range loaded {
+		meta = appendProtoBytes(meta, metaLoadedWritableAddressesField, key[:])
+	}
+
+	info := appendProtoBytes(nil, transactionInfoSignatureField, signature[:])
+	info = appendProtoBytes(info, transactionInfoTransactionField, transaction)
+	info = appendProtoBytes(info, transactionInfoMetaField, meta)
+
+	update := appendProtoBytes(nil, updateTransactionInfoField, info)
+	update = appendProtoVarint(update, updateTransactionSlotField, 350000000)
+
+	// SubscribeUpdate with a filter name ahead of the transaction
+	wrapped := appendProtoBytes(nil, 1, []byte("launchpad"))
+	wrapped = appendProtoBytes(wrapped, subscribeUpdateTransactionField, update)
+	wrapped = appendProtoBytes(wrapped, subscribeUpdateCreatedAtField, appendProtoVarint(nil, timestampSecondsField, 1750000000))
+
+	geyserTx, err := DecodeGeyserTransaction(wrapped)
+	if err != nil {
+		t.Fatalf("Failed to decode Geyser transaction: %v", err)
+	}
+	if geyserTx.Slot != 350000000 || geyserTx.Signature != signature || len(geyserTx.AccountKeys) != 16 {
+		t.Fatalf("Unexpected Geyser transaction: slot %d, signature %s, %d accounts", geyserTx.Slot, geyserTx.Signature, len(geyserTx.AccountKeys))
+	}
+	if geyserTx.Meta.Fee != 5000 || len(geyserTx.Meta.PreBalances) != 2 || geyserTx.Meta.PostTokenBalances[0].Owner != payer {
+		t.Errorf("Unexpected meta: %+v", geyserTx.Meta)
+	}
+	if len(geyserTx.InnerInstructions) != 1 || geyserTx.InnerInstructions[0].Instructions[0].StackHeight != 2 {
+		t.Errorf("Expected one inner instruction at stack height 2, got %+v", geyserTx.InnerInstructions)
+	}
+	if buy := geyserTx.Instructions[0]; !buy.IsSigner[0] || buy.IsSigner[1] || !buy.IsWritable[4] {
+		t.Errorf("Unexpected account flags: signer %v, writable %v", buy.IsSigner, buy.IsWritable)
+	}
+
+	result, err := ParseGeyserTransaction(update)
+	if err != nil {
+		t.Fatalf("Failed to parse Geyser transaction: %v", err)
+	}
+	if len(result.Trade) != 1 {
+		t.Fatalf("Expected 1 trade, got %d", len(result.Trade))
+	}
+	trade := result.Trade[0]
+	if !trade.Pool.Equals(pool) || !trade.TokenOut.Equals(baseMint) || !trade.Trader.Equals(payer) {
+		t.Errorf("Expected accounts from the lookup table, got %+v", trade)
+	}
+	if trade.AmountIn != 200000000 || trade.AmountOut != 7100000000000 {
+		t.Errorf("Expected amounts from the trade event, got %d and %d", trade.AmountIn, trade.AmountOut)
+	}
+
+	result, err = ParseGeyserTransaction(wrapped)
+	if err != nil {
+		t.Fatalf("Failed to parse Geyser transaction: %v", err)
+	}
+	if result.ReceivedAt != 1750000000 || result.BlockTime != 0 || result.Trade[0].Timestamp != 0 {
+		t.Errorf("Expected created_at as the receive time and no block time, got %d and %d", result.ReceivedAt, result.BlockTime)
+	}
+
+	result, err = ParseTransaction(base64.StdEncoding.EncodeToString(wrapped), 0, 1750000005)
+	if err != nil {
+		t.Fatalf("Failed to parse base64 Geyser transaction: %v", err)
+	}
+	if result.ReceivedAt != 1750000000 || result.BlockTime != 1750000005 || result.Trade[0].Timestamp != 1750000005 {
+		t.Errorf("Expected the given block time next to the receive time, got %d and %d", result.BlockTime, result.ReceivedAt)
+	}
+}
+
+func TestWireTransactionIsNotGeyser(t *testing.T) {
+	encoded, _ := buildLaunchpadTradeTx(t, LaunchpadBuyExactIn, 1, 1)
+	if _, err := parseGeyserTransaction(encoded, 1); err == nil {
+		t.Error("Expected a wire-format transaction not to decode as Geyser")
+	}
+}
+
+func TestTenSignerWireTransactionIsNotGeyser(t *testing.T) {
+	// Signers are keys 0 to 9, the Launchpad program is key 10 and the trade accounts follow
+	keys := testKeys(25, testKeys(10)...)
+	keys[10] = RaydiumLaunchpadV1ProgramID
+	accounts := append([]uint16{0}, accountRange(11, 25)...)
+	data := instructionData(LaunchpadInstructions, LaunchpadBuyExactIn, 1000, 1, 0)
+
+	// Signature bytes of 0x0a read as a run of valid protobuf fields behind the 0x0a signature count
+	tx := solana.Transaction{
+		Signatures: make([]solana.Signature, 10),
+		Message: solana.Message{
+			Header:       solana.MessageHeader{NumRequiredSignatures: 10, NumReadonlyUnsignedAccounts: 1},
+			AccountKeys:  keys,
+			Instructions: []solana.CompiledInstruction{{ProgramIDIndex: 10, Accounts: accounts, Data: data}},
+		},
+	}
+	for i := range tx.Signatures {
+		for j := range tx.Signatures[i] {
+			tx.Signatures[i][j] = 0x0a
+		}
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed to encode transaction: %v", err)
+	}
+	if raw[0] != 0x0a {
+		t.Fatalf("Expected the signature count to encode as 0x0a, got %#x", raw[0])
+	}
+	encoded := base64.StdEncoding.EncodeToString(raw)
+
+	if _, err := parseGeyserTransaction(encoded, 1); err == nil {
+		t.Fatal("Expected a 10-signer wire transaction not to decode as Geyser")
+	}
+	result, err := ParseTransaction(encoded, 1, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse transaction: %v", err)
+	}
+	if result.Signature != tx.Signatures[0] || len(result.Trade) != 1 || result.Trade[0].AmountIn != 1000 {
+		t.Errorf("Expected the wire decoder to parse the buy, got signature %s and trades %+v", result.Signature, result.Trade)
+	}
+}
@@ -0,0 +1,658 @@
+package parser
+
+import (
+	"embed"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// Bundled Anchor IDLs, loaded into DefaultIDLs at startup and overridable with WithIDL
+//
+//go:embed idl/*.json
+var bundledIDLs embed.FS
+
+// IDL is an Anchor IDL. Both the legacy (< 0.30) and the current JSON layouts are accepted.
+type IDL struct {
+	Address      string           `json:"address"`
+	Name         string           `json:"name"`
+	Metadata     IDLMetadata      `json:"metadata"`
+	Instructions []IDLInstruction `json:"instructions"`
+	Events       []IDLEvent       `json:"events"`
+	Types        []IDLTypeDef     `json:"types"`
+
+	types map[string]*IDLTypeDef
+}
+
+// IDLMetadata holds the program metadata of a current-format IDL
+type IDLMetadata struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Address string `json:"address"`
+}
+
+// IDLInstruction describes one program instruction
+type IDLInstruction struct {
+	Name          string       `json:"name"`
+	Discriminator []byte       `json:"-"`
+	RawDisc       []int        `json:"discriminator"`
+	Accounts      []IDLAccount `json:"accounts"`
+	Args          []IDLField   `json:"args"`
+}
+
+// IDLAccount is an instruction account; legacy IDLs may nest composite account groups
+type IDLAccount struct {
+	Name       string       `json:"name"`
+	Writable   bool         `json:"writable"`
+	Signer     bool         `json:"signer"`
+	IsMut      bool         `json:"isMut"`
+	IsSigner   bool         `json:"isSigner"`
+	Optional   bool         `json:"optional"`
+	IsOptional bool         `json:"isOptional"`
+	Accounts   []IDLAccount `json:"accounts"`
+}
+
+// IDLEvent describes an event emitted by the program
+type IDLEvent struct {
+	Name          string     `json:"name"`
+	Discriminator []byte     `json:"-"`
+	RawDisc       []int      `json:"discriminator"`
+	Fields        []IDLField `json:"fields"`
+}
+
+// IDLField is a named, typed struct field or instruction argument
+type IDLField struct {
+	Name string  `json:"name"`
+	Type IDLType `json:"type"`
+}
+
+// IDLTypeDef is a user-defined struct or enum
+type IDLTypeDef struct {
+	Name string `json:"name"`
+	Type struct {
+		Kind     string       `json:"kind"`
+		Fields   []IDLField   `json:"fields"`
+		Variants []IDLVariant `json:"variants"`
+	} `json:"type"`
+}
+
+// IDLVariant is one enum variant with optional named or tuple fields
+type IDLVariant struct {
+	Name   string     `json:"name"`
+	Fields []IDLField `json:"-"`
+	Tuple  []IDLType  `json:"-"`
+}
+
+// UnmarshalJSON accepts both named ({"name", "type"}) and tuple (bare type) variant fields
+func (v *IDLVariant) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name   string            `json:"name"`
+		Fields []json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	v.Name = raw.Name
+	for _, f := range raw.Fields {
+		var field IDLField
+		if err := json.Unmarshal(f, &field); err == nil && field.Name != "" {
+			v.Fields = append(v.Fields, field)
+			continue
+		}
+		var t IDLType
+		if err := json.Unmarshal(f, &t); err != nil {
+			return fmt.Errorf("variant %s: %w", raw.Name, err)
+		}
+		v.Tuple = append(v.Tuple, t)
+	}
+	return nil
+}
+
+// IDLType is a primitive ("u64", "pubkey", ...) or a composite (vec, option, array, defined) type
+type IDLType struct {
+	Primitive string
+	Vec       *IDLType
+	Option    *IDLType
+	Array     *IDLType
+	ArrayLen  int
+	Defined   string
+}
+
+// UnmarshalJSON decodes every type spelling used by Anchor IDLs
+func (t *IDLType) UnmarshalJSON(data []byte) error {
+	var primitive string
+	if err := json.Unmarshal(data, &primitive); err == nil {
+		t.Primitive = primitive
+		return nil
+	}
+
+	var composite map[string]json.RawMessage
+	if err := json.Unmarshal(data, &composite); err != nil {
+		return fmt.Errorf("unsupported IDL type %s", string(data))
+	}
+
+	if raw, ok := composite["vec"]; ok {
+		t.Vec = &IDLType{}
+		return json.Unmarshal(raw, t.Vec)
+	}
+	if raw, ok := composite["option"]; ok {
+		t.Option = &IDLType{}
+		return json.Unmarshal(raw, t.Option)
+	}
+	if raw, ok := composite["array"]; ok {
+		var pair []json.RawMessage
+		if err := json.Unmarshal(raw, &pair); err != nil || len(pair) != 2 {
+			return fmt.Errorf("invalid IDL array type %s", string(raw))
+		}
+		t.Array = &IDLType{}
+		if err := json.Unmarshal(pair[0], t.Array); err != nil {
+			return err
+		}
+		return json.Unmarshal(pair[1], &t.ArrayLen)
+	}
+	if raw, ok := composite["defined"]; ok {
+		// Legacy: {"defined": "Name"}, current: {"defined": {"name": "Name"}}
+		if err := json.Unmarshal(raw, &t.Defined); err == nil {
+			return nil
+		}
+		var named struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &named); err != nil {
+			return err
+		}
+		t.Defined = named.Name
+		return nil
+	}
+
+	return fmt.Errorf("unsupported IDL type %s", string(data))
+}
+
+// IDLValues is the generic decoded form of an instruction, struct or event
+type IDLValues map[string]interface{}
+
+// IDLEnum is a decoded enum value
+type IDLEnum struct {
+	Variant string
+	Fields  IDLValues // Named fields, or "0", "1", ... for tuple variants; nil for unit variants
+}
+
+// DecodedInstruction is an instruction decoded through an IDL
+type DecodedInstruction struct {
+	ProgramID solana.PublicKey
+	Name      string
+	Args      IDLValues
+	Accounts  map[string]solana.PublicKey
+}
+
+// ParseIDL parses an Anchor IDL JSON document
+func ParseIDL(data []byte) (*IDL, error) {
+	idl := &IDL{}
+	if err := json.Unmarshal(data, idl); err != nil {
+		return nil, fmt.Errorf("failed to parse IDL: %w", err)
+	}
+
+	if idl.Address == "" {
+		idl.Address = idl.Metadata.Address
+	}
+	if idl.Name == "" {
+		idl.Name = idl.Metadata.Name
+	}
+
+	idl.types = make(map[string]*IDLTypeDef, len(idl.Types))
+	for i := range idl.Types {
+		idl.types[idl.Types[i].Name] = &idl.Types[i]
+	}
+
+	for i := range idl.Instructions {
+		ix := &idl.Instructions[i]
+		ix.Name = toSnakeCase(ix.Name)
+		ix.Discriminator = idlDiscriminator(ix.RawDisc, "global", ix.Name)
+	}
+
+	for i := range idl.Events {
+		ev := &idl.Events[i]
+		ev.Discriminator = idlDiscriminator(ev.RawDisc, "event", ev.Name)
+		// Current IDLs declare the event layout under types
+		if len(ev.Fields) == 0 {
+			if def, ok := idl.types[ev.Name]; ok {
+				ev.Fields = def.Type.Fields
+			}
+		}
+	}
+
+	return idl, nil
+}
+
+// idlDiscriminator uses the declared discriminator or falls back to the Anchor sighash
+func idlDiscriminator(raw []int, namespace, name string) []byte {
+	if len(raw) > 0 {
+		disc := make([]byte, len(raw))
+		for i, b := range raw {
+			disc[i] = byte(b)
+		}
+		return disc
+	}
+	d := AnchorDiscriminator(namespace, name)
+	return d[:]
+}
+
+// Instruction returns the IDL instruction whose discriminator prefixes data
+func (idl *IDL) Instruction(data []byte) (*IDLInstruction, bool) {
+	for i := range idl.Instructions {
+		disc := idl.Instructions[i].Discriminator
+		if len(disc) > 0 && len(data) >= len(disc) && string(data[:len(disc)]) == string(disc) {
+			return &idl.Instructions[i], true
+		}
+	}
+	return nil, false
+}
+
+// DecodeInstructionData decodes instruction arguments without resolving accounts
+func (idl *IDL) DecodeInstructionData(data []byte) (*DecodedInstruction, error) {
+	return idl.DecodeInstruction(data, nil)
+}
+
+// DecodeInstruction decodes instruction arguments and maps the instruction accounts to their IDL names
+func (idl *IDL) DecodeInstruction(data []byte, accounts []solana.PublicKey) (*DecodedInstruction, error) {
+	ix, ok := idl.Instruction(data)
+	if !ok {
+		return nil, fmt.Errorf("no %s instruction matches discriminator %x", idl.Name, data[:min(8, len(data))])
+	}
+
+	decoder := bin.NewBorshDecoder(data[len(ix.Discriminator):])
+	args, err := idl.decodeFields(decoder, ix.Args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s args: %w", ix.Name, err)
+	}
+
+	decoded := &DecodedInstruction{
+		Name:     ix.Name,
+		Args:     args,
+		Accounts: make(map[string]solana.PublicKey),
+	}
+	if idl.Address != "" {
+		decoded.ProgramID, _ = solana.PublicKeyFromBase58(idl.Address)
+	}
+
+	for i, name := range ix.AccountNames() {
+		if i >= len(accounts) {
+			break
+		}
+		decoded.Accounts[name] = accounts[i]
+	}
+
+	return decoded, nil
+}
+
+// DecodeEvent decodes an event body (discriminator included) through the IDL
+func (idl *IDL) DecodeEvent(data []byte) (string, IDLValues, error) {
+	for _, ev := range idl.Events {
+		disc := ev.Discriminator
+		if len(data) < len(disc) || string(data[:len(disc)]) != string(disc) {
+			continue
+		}
+		values, err := idl.decodeFields(bin.NewBorshDecoder(data[len(disc):]), ev.Fields)
+		if err != nil {
+			return ev.Name, nil, fmt.Errorf("failed to decode %s: %w", ev.Name, err)
+		}
+		return ev.Name, values, nil
+	}
+	return "", nil, fmt.Errorf("no %s event matches discriminator %x", idl.Name, data[:min(8, len(data))])
+}
+
+// AccountNames flattens the instruction accounts into positional snake_case names
+func (ix *IDLInstruction) AccountNames() []string {
+	var names []string
+	var walk func(accounts []IDLAccount)
+	walk = func(accounts []IDLAccount) {
+		for _, account := range accounts {
+			if len(account.Accounts) > 0 {
+				walk(account.Accounts)
+				continue
+			}
+			names = append(names, toSnakeCase(account.Name))
+		}
+	}
+	walk(ix.Accounts)
+	return names
+}
+
+func (idl *IDL) decodeFields(decoder *bin.Decoder, fields []IDLField) (IDLValues, error) {
+	values := make(IDLValues, len(fields))
+	for _, field := range fields {
+		value, err := idl.decodeValue(decoder, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		values[toSnakeCase(field.Name)] = value
+	}
+	return values, nil
+}
+
+func (idl *IDL) decodeValue(decoder *bin.Decoder, t IDLType) (interface{}, error) {
+	switch {
+	case t.Vec != nil:
+		length, err := decoder.ReadUint32(binary.LittleEndian)
+		if err != nil {
+			return nil, err
+		}
+		if int(length) > decoder.Remaining() {
+			return nil, fmt.Errorf("vec length %d exceeds remaining %d bytes", length, decoder.Remaining())
+		}
+		items := make([]interface{}, 0, length)
+		for i := uint32(0); i < length; i++ {
+			item, err := idl.decodeValue(decoder, *t.Vec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case t.Option != nil:
+		present, err := decoder.ReadBool()
+		if err != nil {
+			return nil, err
+		}
+		if !present {
+			return nil, nil
+		}
+		return idl.decodeValue(decoder, *t.Option)
+	case t.Array != nil:
+		if t.Array.Primitive == "u8" {
+			return decoder.ReadNBytes(t.ArrayLen)
+		}
+		items := make([]interface{}, 0, t.ArrayLen)
+		for i := 0; i < t.ArrayLen; i++ {
+			item, err := idl.decodeValue(decoder, *t.Array)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case t.Defined != "":
+		return idl.decodeDefined(decoder, t.Defined)
+	default:
+		return decodePrimitive(decoder, t.Primitive)
+	}
+}
+
+func (idl *IDL) decodeDefined(decoder *bin.Decoder, name string) (interface{}, error) {
+	def, ok := idl.types[name]
+	if !ok {
+		return nil, fmt.Errorf("undefined IDL type %s", name)
+	}
+
+	switch def.Type.Kind {
+	case "struct":
+		return idl.decodeFields(decoder, def.Type.Fields)
+	case "enum":
+		index, err := decoder.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		if int(index) >= len(def.Type.Variants) {
+			return nil, fmt.Errorf("enum %s has no variant %d", name, index)
+		}
+		variant := def.Type.Variants[index]
+		value := IDLEnum{Variant: variant.Name}
+		if len(variant.Fields) > 0 {
+			if value.Fields, err = idl.decodeFields(decoder, variant.Fields); err != nil {
+				return nil, err
+			}
+		} else if len(variant.Tuple) > 0 {
+			value.Fields = make(IDLValues, len(variant.Tuple))
+			for i, fieldType := range variant.Tuple {
+				if value.Fields[fmt.Sprint(i)], err = idl.decodeValue(decoder, fieldType); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unsupported kind %q for IDL type %s", def.Type.Kind, name)
+	}
+}
+
+func decodePrimitive(decoder *bin.Decoder, primitive string) (interface{}, error) {
+	switch primitive {
+	case "bool":
+		return decoder.ReadBool()
+	case "u8":
+		return decoder.ReadUint8()
+	case "i8":
+		return decoder.ReadInt8()
+	case "u16":
+		return decoder.ReadUint16(binary.LittleEndian)
+	case "i16":
+		return decoder.ReadInt16(binary.LittleEndian)
+	case "u32":
+		return decoder.ReadUint32(binary.LittleEndian)
+	case "i32":
+		return decoder.ReadInt32(binary.LittleEndian)
+	case "u64":
+		return decoder.ReadUint64(binary.LittleEndian)
+	case "i64":
+		return decoder.ReadInt64(binary.LittleEndian)
+	case "u128":
+		return decoder.ReadUint128(binary.LittleEndian)
+	case "i128":
+		return decoder.ReadInt128(binary.LittleEndian)
+	case "f32":
+		return decoder.ReadFloat32(binary.LittleEndian)
+	case "f64":
+		return decoder.ReadFloat64(binary.LittleEndian)
+	case "string":
+		return decoder.ReadString()
+	case "bytes":
+		return decoder.ReadByteSlice()
+	case "pubkey", "publicKey":
+		raw, err := decoder.ReadNBytes(solana.PublicKeyLength)
+		if err != nil {
+			return nil, err
+		}
+		return solana.PublicKeyFromBytes(raw), nil
+	default:
+		return nil, fmt.Errorf("unsupported IDL primitive %q", primitive)
+	}
+}
+
+// toSnakeCase converts legacy camelCase IDL names to the snake_case used on chain
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Uint64 returns a numeric field as uint64, or 0 if it is missing
+func (v IDLValues) Uint64(name string) uint64 {
+	switch n := v[name].(type) {
+	case uint64:
+		return n
+	case uint32:
+		return uint64(n)
+	case uint16:
+		return uint64(n)
+	case uint8:
+		return uint64(n)
+	default:
+		return 0
+	}
+}
+
+// Int32 returns an i32 field, or 0 if it is missing
+func (v IDLValues) Int32(name string) int32 {
+	n, _ := v[name].(int32)
+	return n
+}
+
+// Uint8 returns a u8 field, or 0 if it is missing
+func (v IDLValues) Uint8(name string) uint8 {
+	n, _ := v[name].(uint8)
+	return n
+}
+
+// Bool returns a bool field, or false if it is missing
+func (v IDLValues) Bool(name string) bool {
+	b, _ := v[name].(bool)
+	return b
+}
+
+// String returns a string field, or "" if it is missing
+func (v IDLValues) String(name string) string {
+	s, _ := v[name].(string)
+	return s
+}
+
+// PublicKey returns a pubkey field, or the zero key if it is missing
+func (v IDLValues) PublicKey(name string) solana.PublicKey {
+	key, _ := v[name].(solana.PublicKey)
+	return key
+}
+
+// Struct returns a nested struct field, or nil if it is missing
+func (v IDLValues) Struct(name string) IDLValues {
+	s, _ := v[name].(IDLValues)
+	return s
+}
+
+// Enum returns an enum field, or the zero IDLEnum if it is missing
+func (v IDLValues) Enum(name string) IDLEnum {
+	e, _ := v[name].(IDLEnum)
+	return e
+}
+
+// IDLRegistry holds the IDLs instructions are decoded through, keyed by program ID
+type IDLRegistry map[solana.PublicKey]*IDL
+
+// DefaultIDLs holds the bundled IDLs
+var DefaultIDLs = IDLRegistry{}
+
+func init() {
+	entries, err := bundledIDLs.ReadDir("idl")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		data, err := bundledIDLs.ReadFile("idl/" + entry.Name())
+		if err != nil {
+			panic(err)
+		}
+		programID, idl, err := parseIDLWithAddress(data)
+		if err != nil {
+			panic(fmt.Sprintf("bundled IDL %s: %v", entry.Name(), err))
+		}
+		DefaultIDLs[programID] = idl
+	}
+}
+
+// RegisterIDL makes the default parser decode instructions of the given program through idl.
+// Parsers built with New take theirs through WithIDL.
+func RegisterIDL(programID solana.PublicKey, idl *IDL) {
+	configureDefaultParser(WithIDL(programID, idl))
+}
+
+// GetIDL returns the IDL the default parser uses for a program
+func GetIDL(programID solana.PublicKey) (*IDL, bool) {
+	return defaultParser().idl(programID)
+}
+
+// idl returns the IDL the parser uses for a program
+func (p *Parser) idl(programID solana.PublicKey) (*IDL, bool) {
+	idl, ok := p.idls[programID]
+	return idl, ok
+}
+
+// LoadIDLFile loads an Anchor IDL JSON file and registers it with the default parser under its
+// declared address, replacing any bundled IDL for the same program
+func LoadIDLFile(path string) (*IDL, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read IDL %s: %w", path, err)
+	}
+	programID, idl, err := parseIDLWithAddress(data)
+	if err != nil {
+		return nil, err
+	}
+	RegisterIDL(programID, idl)
+	return idl, nil
+}
+
+// parseIDLWithAddress parses an IDL along with the program address it declares
+func parseIDLWithAddress(data []byte) (solana.PublicKey, *IDL, error) {
+	idl, err := ParseIDL(data)
+	if err != nil {
+		return solana.PublicKey{}, nil, err
+	}
+	programID, err := solana.PublicKeyFromBase58(idl.Address)
+	if err != nil {
+		return solana.PublicKey{}, nil, fmt.Errorf("IDL %s has no valid program address: %w", idl.Name, err)
+	}
+	return programID, idl, nil
+}
+
+// decodeInstructionWithIDL decodes instruction data and its resolved accounts through the IDL the parser
+// has for the program. Data no IDL instruction matches is an unknown discriminator, arguments that
+// don't decode are malformed.
+func (p *Parser) decodeInstructionWithIDL(programID solana.PublicKey, data []byte, accounts []solana.PublicKey) (*DecodedInstruction, error) {
+	idl, ok := p.idl(programID)
+	if !ok || idl == nil {
+		return nil, fmt.Errorf("%w: no IDL for program %s", ErrUnknownDiscriminator, programID)
+	}
+	if _, ok := idl.Instruction(data); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDiscriminator, idl.Name)
+	}
+	decoded, err := idl.DecodeInstruction(data, accounts)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedData, err)
+	}
+	decoded.ProgramID = programID
+	return decoded, nil
+}
+
+// decodeWithIDL decodes a compiled instruction through the IDL registered for its program
+func (p *Parser) decodeWithIDL(instruction solana.CompiledInstruction, message *solana.Message) (*DecodedInstruction, bool) {
+	if int(instruction.ProgramIDIndex) >= len(message.AccountKeys) {
+		return nil, false
+	}
+	programID := message.AccountKeys[instruction.ProgramIDIndex]
+	if _, ok := p.idl(programID); !ok {
+		return nil, false
+	}
+
+	decoded, err := p.decodeInstructionWithIDL(programID, instruction.Data, instructionAccounts(instruction, message))
+	if err != nil {
+		p.logger.Printf("IDL decode failed for %s: %v", programID, err)
+		return nil, false
+	}
+	return decoded, true
+}
+
+// decodeGeyserWithIDL decodes an already-resolved Geyser instruction through its program IDL
+func (p *Parser) decodeGeyserWithIDL(instruction GeyserInstruction) (*DecodedInstruction, bool) {
+	if _, ok := p.idl(instruction.ProgramID); !ok {
+		return nil, false
+	}
+	decoded, err := p.decodeInstructionWithIDL(instruction.ProgramID, instruction.Data, instruction.Accounts)
+	if err != nil {
+		p.logger.Printf("IDL decode failed for %s: %v", instruction.ProgramID, err)
+		return nil, false
+	}
+	return decoded, true
+}
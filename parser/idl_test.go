@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestBundledLaunchpadIDL(t *testing.T) {
+	idl, ok := GetIDL(RaydiumLaunchpadV1ProgramID)
+	if !ok {
+		t.Fatalf("Expected bundled Launchpad IDL to be registered")
+	}
+
+	for _, ix := range idl.Instructions {
+		disc, ok := LaunchpadInstructions.Discriminator(ix.Name)
+		if !ok {
+			t.Errorf("IDL instruction %s is missing from the discriminator registry", ix.Name)
+			continue
+		}
+		if string(disc[:]) != string(ix.Discriminator) {
+			t.Errorf("Discriminator mismatch for %s: registry %s, IDL %x", ix.Name, disc, ix.Discriminator)
+		}
+	}
+}
+
+func TestDecodeLaunchpadInitialize(t *testing.T) {
+	disc, _ := LaunchpadInstructions.Discriminator(LaunchpadInitialize)
+	data := append([]byte{}, disc[:]...)
+	data = append(data, 6) // decimals
+	data = appendBorshString(data, "Bonk Test")
+	data = appendBorshString(data, "BTEST")
+	data = appendBorshString(data, "https://example.com/btest.json")
+	data = append(data, 0) // CurveParams::Constant
+	data = binary.LittleEndian.AppendUint64(data, 1000000000000000)
+	data = binary.LittleEndian.AppendUint64(data, 793100000000000)
+	data = binary.LittleEndian.AppendUint64(data, 85000000000)
+	data = append(data, 1) // migrate_type
+	data = binary.LittleEndian.AppendUint64(data, 0)
+	data = binary.LittleEndian.AppendUint64(data, 0)
+	data = binary.LittleEndian.AppendUint64(data, 0)
+
+	accounts := make([]solana.PublicKey, 18)
+	for i := range accounts {
+		accounts[i] = solana.NewWallet().PublicKey()
+	}
+
+	idl, _ := GetIDL(RaydiumLaunchpadV1ProgramID)
+	decoded, err := idl.DecodeInstruction(data, accounts)
+	if err != nil {
+		t.Fatalf("Failed to decode initialize: %v", err)
+	}
+
+	if decoded.Name != LaunchpadInitialize {
+		t.Errorf("Expected name %s, got %s", LaunchpadInitialize, decoded.Name)
+	}
+	if symbol := decoded.Args.Struct("base_mint_param").String("symbol"); symbol != "BTEST" {
+		t.Errorf("Expected symbol BTEST, got %s", symbol)
+	}
+	curve := decoded.Args.Enum("curve_param")
+	if curve.Variant != "Constant" || curve.Fields.Struct("data").Uint64("supply") != 1000000000000000 {
+		t.Errorf("Unexpected curve param: %+v", curve)
+	}
+	if !decoded.Accounts["base_mint"].Equals(accounts[6]) || !decoded.Accounts["pool_state"].Equals(accounts[5]) {
+		t.Errorf("Accounts were not mapped by name: %v", decoded.Accounts)
+	}
+
+	// Truncated data must fail instead of decoding garbage
+	if _, err := idl.DecodeInstructionData(data[:20]); err == nil {
+		t.Errorf("Expected error decoding truncated initialize")
+	}
+}
+
+func TestParseLegacyIDL(t *testing.T) {
+	legacy := `{
+		"version": "0.1.0",
+		"name": "legacy_program",
+		"instructions": [{
+			"name": "swapBaseInput",
+			"accounts": [
+				{"name": "payer", "isMut": false, "isSigner": true},
+				{"name": "poolAccounts", "accounts": [{"name": "poolState", "isMut": true, "isSigner": false}]}
+			],
+			"args": [{"name": "amountIn", "type": "u64"}, {"name": "minimumAmountOut", "type": "u64"}]
+		}],
+		"metadata": {"address": "CPMMoo8L3F4NbTegBCKVNunggL7H1ZpdTHKxQB5qKP1C"}
+	}`
+
+	idl, err := ParseIDL([]byte(legacy))
+	if err != nil {
+		t.Fatalf("Failed to parse legacy IDL: %v", err)
+	}
+
+	disc := AnchorDiscriminator("global", "swap_base_input")
+	data := append([]byte{}, disc[:]...)
+	data = binary.LittleEndian.AppendUint64(data, 500)
+	data = binary.LittleEndian.AppendUint64(data, 450)
+
+	payer, pool := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	decoded, err := idl.DecodeInstruction(data, []solana.PublicKey{payer, pool})
+	if err != nil {
+		t.Fatalf("Failed to decode legacy instruction: %v", err)
+	}
+
+	if decoded.Name != "swap_base_input" {
+		t.Errorf("Expected snake_case name, got %s", decoded.Name)
+	}
+	if decoded.Args.Uint64("amount_in") != 500 || decoded.Args.Uint64("minimum_amount_out") != 450 {
+		t.Errorf("Unexpected args: %v", decoded.Args)
+	}
+	if !decoded.Accounts["pool_state"].Equals(pool) {
+		t.Errorf("Expected nested pool_state account to be flattened")
+	}
+}
+
+func appendBorshString(data []byte, s string) []byte {
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(s)))
+	return append(data, s...)
+}
+
+func TestWithIDLLeavesOtherParsersAlone(t *testing.T) {
+	bundled, _ := GetIDL(RaydiumLaunchpadV1ProgramID)
+	programID := solana.NewWallet().PublicKey()
+
+	p := New(WithIDL(programID, bundled))
+	if idl, ok := p.idl(programID); !ok || idl != bundled {
+		t.Errorf("Expected the parser to use the given IDL, got %v", idl)
+	}
+	if idl, ok := p.idl(RaydiumLaunchpadV1ProgramID); !ok || idl != bundled {
+		t.Errorf("Expected the parser to keep the bundled Launchpad IDL, got %v", idl)
+	}
+	if _, ok := DefaultIDLs[programID]; ok {
+		t.Error("Expected WithIDL to leave DefaultIDLs unchanged")
+	}
+	if _, ok := GetIDL(programID); ok {
+		t.Error("Expected WithIDL to leave the default parser unchanged")
+	}
+}
+
+func TestWithIDLDecodesCpmm(t *testing.T) {
+	// A CPMM IDL whose swap_base_input carries a made-up discriminator
+	accounts := make([]map[string]string, len(cpmmSwapLayout))
+	for i, name := range cpmmSwapLayout {
+		accounts[i] = map[string]string{"name": name}
+	}
+	doc, _ := json.Marshal(map[string]interface{}{
+		"address":  RaydiumCpSwapProgramID.String(),
+		"metadata": map[string]string{"name": "raydium_cp_swap"},
+		"instructions": []map[string]interface{}{{
+			"name":          "swap_base_input",
+			"discriminator": []int{1, 2, 3, 4, 5, 6, 7, 8},
+			"accounts":      accounts,
+			"args":          []map[string]string{{"name": "amount_in", "type": "u64"}, {"name": "minimum_amount_out", "type": "u64"}},
+		}},
+	})
+	idl, err := ParseIDL(doc)
+	if err != nil {
+		t.Fatalf("Failed to parse IDL: %v", err)
+	}
+
+	keys := testKeys(15, solana.NewWallet().PublicKey(), RaydiumCpSwapProgramID)
+	data := appendU64s([]byte{1, 2, 3, 4, 5, 6, 7, 8}, 1000000000, 3500000000)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: append([]uint16{0}, accountRange(3, 15)...), Data: data})
+
+	if result := mustParse(t, encoded, 0, &TransactionMeta{}); len(result.Trade) != 0 || len(result.Diagnostics) != 1 {
+		t.Errorf("Expected the bundled IDL not to know the discriminator, got %+v, %v", result.Trade, result.Diagnostics)
+	}
+
+	result, err := New(WithIDL(RaydiumCpSwapProgramID, idl)).ParseTransactionWithMeta(encoded, 1, 0, solana.Signature{}, &TransactionMeta{})
+	if err != nil {
+		t.Fatalf("Failed to parse transaction: %v", err)
+	}
+	if len(result.Trade) != 1 || result.Trade[0].AmountIn != 1000000000 || result.Trade[0].MinAmountOut != 3500000000 || !result.Trade[0].Pool.Equals(keys[5]) {
+		t.Errorf("Expected the swap decoded through the given IDL, got %+v", result.Trade)
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package parser
 
 import (
 	"encoding/binary"
@@ -160,16 +160,14 @@ func (s *SwapInstruction) SetMinimumAmountOut(minimumAmountOut uint64) *SwapInst
 // Build creates the Solana instruction
 func (s *SwapInstruction) Build() (solana.Instruction, error) {
 	// Build instruction data
-	data := make([]byte, 17) // 1 byte discriminator + 8 bytes amountIn + 8 bytes minimumAmountOut
-	data[0] = INSTRUCTION_SWAP
+	data := make([]byte, 17) // 1 byte opcode + 8 bytes amountIn + 8 bytes minimumAmountOut
+	data[0] = AMM_V4_SWAP_BASE_IN
 	binary.LittleEndian.PutUint64(data[1:9], s.amountIn)
 	binary.LittleEndian.PutUint64(data[9:17], s.minimumAmountOut)
 
-	// Build accounts slice
+	// Build accounts slice in the order of the AMM v4 swap layout
 	accounts := solana.AccountMetaSlice{
-		{PublicKey: s.userSourceToken, IsWritable: true, IsSigner: false},
-		{PublicKey: s.userDestToken, IsWritable: true, IsSigner: false},
-		{PublicKey: s.userOwner, IsWritable: false, IsSigner: true},
+		{PublicKey: TokenProgramID, IsWritable: false, IsSigner: false},
 		{PublicKey: s.ammID, IsWritable: true, IsSigner: false},
 		{PublicKey: s.ammAuthority, IsWritable: false, IsSigner: false},
 		{PublicKey: s.ammOpenOrders, IsWritable: true, IsSigner: false},
@@ -184,7 +182,9 @@ func (s *SwapInstruction) Build() (solana.Instruction, error) {
 		{PublicKey: s.serumCoinVault, IsWritable: true, IsSigner: false},
 		{PublicKey: s.serumPcVault, IsWritable: true, IsSigner: false},
 		{PublicKey: s.serumVaultSigner, IsWritable: false, IsSigner: false},
-		{PublicKey: TokenProgramID, IsWritable: false, IsSigner: false},
+		{PublicKey: s.userSourceToken, IsWritable: true, IsSigner: false},
+		{PublicKey: s.userDestToken, IsWritable: true, IsSigner: false},
+		{PublicKey: s.userOwner, IsWritable: false, IsSigner: true},
 	}
 
 	return solana.NewInstruction(
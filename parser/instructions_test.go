@@ -1,4 +1,4 @@
-package main
+package parser
 
 import (
 	"context"
@@ -59,9 +59,12 @@ func TestSwapInstructionBuilder(t *testing.T) {
 		t.Errorf("Expected 17 bytes of data, got %d", len(data))
 	}
 
-	// Verify discriminator
-	if data[0] != INSTRUCTION_SWAP {
-		t.Errorf("Expected discriminator %d, got %d", INSTRUCTION_SWAP, data[0])
+	// Verify opcode
+	if data[0] != AMM_V4_SWAP_BASE_IN {
+		t.Errorf("Expected opcode %d, got %d", AMM_V4_SWAP_BASE_IN, data[0])
+	}
+	if !accounts[17].PublicKey.Equals(solana.MustPublicKeyFromBase58("HN7cABqLq46Es1jh92dQQisAq662SmxELLLsHHe4YWrH")) || !accounts[17].IsSigner {
+		t.Errorf("Expected the user owner to sign as the last account, got %s", accounts[17].PublicKey)
 	}
 
 	t.Logf("✓ Swap instruction built successfully with %d accounts and %d bytes of data", len(accounts), len(data))
@@ -350,7 +353,7 @@ func TestTransactionParsingWithLiveData(t *testing.T) {
 // TestParsingWithSampleData tests parsing with sample transaction data
 func TestParsingWithSampleData(t *testing.T) {
 	// Read sample transaction data
-	sampleData, err := os.ReadFile("sample_transaction.txt")
+	sampleData, err := os.ReadFile("../sample_transaction.txt")
 	if err != nil {
 		t.Skipf("Sample transaction file not found: %v", err)
 	}
@@ -362,7 +365,7 @@ func TestParsingWithSampleData(t *testing.T) {
 			continue
 		}
 
-		parsedTx, err := ParseTransaction(line, uint64(12345+i))
+		parsedTx, err := ParseTransaction(line, uint64(12345+i), 0)
 		if err != nil {
 			t.Logf("Failed to parse transaction %d: %v", i, err)
 			continue
@@ -437,7 +440,7 @@ func TestLaunchpadTransactionParsing(t *testing.T) {
 	mockLaunchpadTx := createMockLaunchpadTransaction(demoTxSignature)
 
 	// Parse the transaction
-	result, err := ParseTransaction(mockLaunchpadTx, 250000000)
+	result, err := ParseTransaction(mockLaunchpadTx, 250000000, 0)
 	if err != nil {
 		t.Logf("Transaction parsing failed (expected for demo): %v", err)
 		// This is expected to fail with the current mock data
@@ -504,7 +507,7 @@ func TestLaunchpadInstructionTypes(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			mockTx := createMockLaunchpadInstructionTransaction(tc.discriminator)
-			result, err := ParseTransaction(mockTx, 250000000)
+			result, err := ParseTransaction(mockTx, 250000000, 0)
 
 			if err != nil {
 				t.Logf("Expected parsing failure for mock data: %v", err)
@@ -579,9 +582,14 @@ func TestLiveLaunchpadTransactionParsing(t *testing.T) {
 
 	// Parse transaction
 	encoded := txResp.Transaction.GetBinary()
+	var blockTime int64
+	if txResp.BlockTime != nil {
+		blockTime = int64(*txResp.BlockTime)
+	}
 	result, err := ParseTransactionWithSignature(
 		base64.StdEncoding.EncodeToString(encoded),
 		txResp.Slot,
+		blockTime,
 		signature,
 	)
 
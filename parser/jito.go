@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"encoding/binary"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// JitoTipAccounts are the accounts the Jito block engine accepts tips on
+var JitoTipAccounts = map[solana.PublicKey]bool{
+	solana.MustPublicKeyFromBase58("96gYZGLnJYVFmbjzopPSU6QiEV5fGqZNyN9nmNhvrZU5"): true,
+	solana.MustPublicKeyFromBase58("HFqU5x63VTqvQss8hp11i4wVV8bD44PvwucfZ2bU7gRe"): true,
+	solana.MustPublicKeyFromBase58("Cw8CFyM9FkoMi7K7Crf6HNQqf4uEMzpKw6QNghXLvLkY"): true,
+	solana.MustPublicKeyFromBase58("ADaUMid9yfUytqMBgopwjb2DTLSokTSzL1zt6iGPaS49"): true,
+	solana.MustPublicKeyFromBase58("DfXygSm4jCyNCybVYYK6DwvWqjKee8pbDmJGcLWNDXjh"): true,
+	solana.MustPublicKeyFromBase58("ADuUkR4vqLUMWXxW9gh6D6L8pMSawimctcNZ5pGwDcEt"): true,
+	solana.MustPublicKeyFromBase58("DttWaMuVvTiduZRnguLF7jNxTgiMBZ1hyAumKUiL2KRL"): true,
+	solana.MustPublicKeyFromBase58("3AVi9Tg9Uo68tJfuvoKvqKNWKkC5wPdSSdeBnizKZ6jT"): true,
+}
+
+// System program Transfer instruction
+const systemTransferInstruction = 2
+
+// decodeSystemTransfer is the ProgramDecoder of the System program, which only looks for Jito tips
+func decodeSystemTransfer(ctx *InstructionContext, result *Transaction) error {
+	return ctx.parser().parseSystemTransfer(ctx.Data, ctx.Accounts, ctx.Index, result)
+}
+
+// parseSystemTransfer records System transfers to a Jito tip account as a tip
+func (p *Parser) parseSystemTransfer(data []byte, accounts []solana.PublicKey, index int, result *Transaction) error {
+	if len(data) < 12 || binary.LittleEndian.Uint32(data) != systemTransferInstruction || len(accounts) < 2 {
+		return nil
+	}
+	if !JitoTipAccounts[accounts[1]] {
+		return nil
+	}
+
+	lamports := binary.LittleEndian.Uint64(data[4:12])
+	result.JitoTip += lamports
+	result.JitoTipAccount = accounts[1]
+	p.logger.Printf("Jito tip at index %d: %d lamports to %s", index, lamports, accounts[1])
+	return nil
+}
+
+// Bundle is a group of transactions from one slot that were likely submitted together as a Jito bundle
+type Bundle struct {
+	Slot         uint64
+	Transactions []*Transaction
+	Tip          uint64             // Total Jito tip paid by the transactions of the bundle
+	Pools        []solana.PublicKey // Pools created or traded by the bundle
+	Launch       bool               // The bundle creates a pool, i.e. a bundled launch
+}
+
+// GroupBundles groups transactions from the same slot into likely bundles. Transactions that create
+// or trade the same pool are grouped together, and only groups of two or more transactions where at
+// least one paid a Jito tip are returned.
+func GroupBundles(txs []*Transaction) []Bundle {
+	var bundles []Bundle
+
+	bySlot := make(map[uint64][]*Transaction)
+	var slots []uint64
+	for _, tx := range txs {
+		if _, ok := bySlot[tx.Slot]; !ok {
+			slots = append(slots, tx.Slot)
+		}
+		bySlot[tx.Slot] = append(bySlot[tx.Slot], tx)
+	}
+
+	for _, slot := range slots {
+		slotTxs := bySlot[slot]
+
+		// Union transactions that share a pool
+		parent := make([]int, len(slotTxs))
+		for i := range parent {
+			parent[i] = i
+		}
+		var find func(int) int
+		find = func(i int) int {
+			if parent[i] != i {
+				parent[i] = find(parent[i])
+			}
+			return parent[i]
+		}
+		firstByPool := make(map[solana.PublicKey]int)
+		for i, tx := range slotTxs {
+			for _, pool := range transactionPools(tx) {
+				if j, ok := firstByPool[pool]; ok {
+					parent[find(i)] = find(j)
+				} else {
+					firstByPool[pool] = i
+				}
+			}
+		}
+
+		groups := make(map[int][]*Transaction)
+		var roots []int
+		for i, tx := range slotTxs {
+			root := find(i)
+			if _, ok := groups[root]; !ok {
+				roots = append(roots, root)
+			}
+			groups[root] = append(groups[root], tx)
+		}
+
+		for _, root := range roots {
+			group := groups[root]
+			if len(group) < 2 {
+				continue
+			}
+			bundle := Bundle{Slot: slot, Transactions: group}
+			seen := make(map[solana.PublicKey]bool)
+			for _, tx := range group {
+				bundle.Tip += tx.JitoTip
+				bundle.Launch = bundle.Launch || len(tx.Create) > 0
+				for _, pool := range transactionPools(tx) {
+					if !seen[pool] {
+						seen[pool] = true
+						bundle.Pools = append(bundle.Pools, pool)
+					}
+				}
+			}
+			if bundle.Tip > 0 {
+				bundles = append(bundles, bundle)
+			}
+		}
+	}
+
+	return bundles
+}
+
+// transactionPools returns the pools a transaction creates or trades
+func transactionPools(tx *Transaction) []solana.PublicKey {
+	var pools []solana.PublicKey
+	for _, create := range tx.Create {
+		if !create.PoolAddress.IsZero() {
+			pools = append(pools, create.PoolAddress)
+		}
+	}
+	for _, trade := range tx.Trade {
+		if !trade.Pool.IsZero() {
+			pools = append(pools, trade.Pool)
+		}
+	}
+	return pools
+}
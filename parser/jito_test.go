@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestJitoTipDetected(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	tipAccount := solana.MustPublicKeyFromBase58("96gYZGLnJYVFmbjzopPSU6QiEV5fGqZNyN9nmNhvrZU5")
+	other := solana.NewWallet().PublicKey()
+	keys := solana.PublicKeySlice{payer, tipAccount, other, SystemProgramID}
+
+	transfer := func(lamports uint64) []byte {
+		data := binary.LittleEndian.AppendUint32(nil, systemTransferInstruction)
+		return binary.LittleEndian.AppendUint64(data, lamports)
+	}
+
+	encoded := encodeTransaction(t, keys, 1,
+		solana.CompiledInstruction{ProgramIDIndex: 3, Accounts: []uint16{0, 2}, Data: transfer(5000000)},
+		solana.CompiledInstruction{ProgramIDIndex: 3, Accounts: []uint16{0, 1}, Data: transfer(1000000)})
+
+	result := mustParse(t, encoded, 0, nil)
+	if result.JitoTip != 1000000 || !result.JitoTipAccount.Equals(tipAccount) {
+		t.Errorf("Expected a 1000000 lamport tip to %s, got %d to %s", tipAccount, result.JitoTip, result.JitoTipAccount)
+	}
+}
+
+func TestGroupBundles(t *testing.T) {
+	pool := solana.NewWallet().PublicKey()
+	otherPool := solana.NewWallet().PublicKey()
+
+	launch := &Transaction{Slot: 10, JitoTip: 100000, Create: []CreateInfo{{PoolAddress: pool}}}
+	snipe := &Transaction{Slot: 10, Trade: []TradeInfo{{Pool: pool}}}
+	unrelated := &Transaction{Slot: 10, Trade: []TradeInfo{{Pool: otherPool}}}
+	laterSlot := &Transaction{Slot: 11, Trade: []TradeInfo{{Pool: pool}}}
+	untipped := []*Transaction{
+		{Slot: 12, Trade: []TradeInfo{{Pool: otherPool}}},
+		{Slot: 12, Trade: []TradeInfo{{Pool: otherPool}}},
+	}
+
+	bundles := GroupBundles(append([]*Transaction{launch, snipe, unrelated, laterSlot}, untipped...))
+	if len(bundles) != 1 {
+		t.Fatalf("Expected 1 bundle, got %d", len(bundles))
+	}
+
+	bundle := bundles[0]
+	if bundle.Slot != 10 || len(bundle.Transactions) != 2 || bundle.Tip != 100000 || !bundle.Launch {
+		t.Errorf("Unexpected bundle: %+v", bundle)
+	}
+	if bundle.Transactions[0] != launch || bundle.Transactions[1] != snipe {
+		t.Errorf("Expected the launch and the snipe in block order")
+	}
+}
@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"slices"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// AccountLayout names the accounts of an instruction in the order the program expects them
+type AccountLayout []string
+
+// Launchpad account layouts, as declared in the program IDL
+var (
+	launchpadTradeLayout = AccountLayout{
+		"payer", "authority", "global_config", "platform_config", "pool_state",
+		"user_base_token", "user_quote_token", "base_vault", "quote_vault",
+		"base_token_mint", "quote_token_mint", "base_token_program", "quote_token_program",
+		"event_authority", "program",
+	}
+	launchpadInitializeLayout = AccountLayout{
+		"payer", "creator", "global_config", "platform_config", "authority", "pool_state",
+		"base_mint", "quote_mint", "base_vault", "quote_vault", "metadata_account",
+		"base_token_program", "quote_token_program", "metadata_program", "system_program",
+		"rent_program", "event_authority", "program",
+	}
+	launchpadInitializeToken2022Layout = AccountLayout{
+		"payer", "creator", "global_config", "platform_config", "authority", "pool_state",
+		"base_mint", "quote_mint", "base_vault", "quote_vault",
+		"base_token_program", "quote_token_program", "system_program", "event_authority", "program",
+	}
+	launchpadMigrateToAmmLayout = AccountLayout{
+		"payer", "base_mint", "quote_mint", "openbook_program", "market", "request_queue", "event_queue",
+		"bids", "asks", "market_vault_signer", "market_base_vault", "market_quote_vault", "amm_program",
+		"amm_pool", "amm_authority", "amm_open_orders", "amm_lp_mint", "amm_base_vault", "amm_quote_vault",
+		"amm_target_orders", "amm_config", "amm_create_fee_destination", "authority", "pool_state",
+		"global_config", "base_vault", "quote_vault", "pool_lp_token", "spl_token_program",
+		"associated_token_program", "system_program", "rent_program",
+	}
+	launchpadMigrateToCpswapLayout = AccountLayout{
+		"payer", "base_mint", "quote_mint", "platform_config", "cpswap_program", "cpswap_pool",
+		"cpswap_authority", "cpswap_lp_mint", "cpswap_base_vault", "cpswap_quote_vault", "cpswap_config",
+		"cpswap_create_pool_fee", "cpswap_observation", "lock_program", "lock_authority", "lock_lp_vault",
+		"authority", "pool_state", "global_config", "base_vault", "quote_vault", "pool_lp_token",
+		"base_token_program", "quote_token_program", "associated_token_program", "system_program",
+		"rent_program", "metadata_program",
+	}
+)
+
+// LaunchpadAccountLayouts maps Launchpad instruction names to their account layouts
+var LaunchpadAccountLayouts = map[string]AccountLayout{
+	LaunchpadBuyExactIn:              launchpadTradeLayout,
+	LaunchpadBuyExactOut:             launchpadTradeLayout,
+	LaunchpadSellExactIn:             launchpadTradeLayout,
+	LaunchpadSellExactOut:            launchpadTradeLayout,
+	LaunchpadInitialize:              launchpadInitializeLayout,
+	LaunchpadInitializeV2:            launchpadInitializeLayout,
+	LaunchpadInitializeWithToken2022: launchpadInitializeToken2022Layout,
+	LaunchpadMigrateToAmm:            launchpadMigrateToAmmLayout,
+	LaunchpadMigrateToCpswap:         launchpadMigrateToCpswapLayout,
+}
+
+// Index returns the position of a named account in the layout, or -1
+func (l AccountLayout) Index(name string) int {
+	for i, account := range l {
+		if account == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Without returns a copy of the layout with the named accounts left out
+func (l AccountLayout) Without(names ...string) AccountLayout {
+	without := make(AccountLayout, 0, len(l))
+	for _, account := range l {
+		if !slices.Contains(names, account) {
+			without = append(without, account)
+		}
+	}
+	return without
+}
+
+// Resolve names the accounts of an instruction. Names past the end of a short account list are left out.
+func (l AccountLayout) Resolve(accounts []solana.PublicKey) map[string]solana.PublicKey {
+	named := make(map[string]solana.PublicKey, len(l))
+	for i, name := range l {
+		if i >= len(accounts) {
+			break
+		}
+		named[name] = accounts[i]
+	}
+	return named
+}
+
+// instructionAccounts returns the accounts a compiled instruction references, stopping at the
+// first index outside the message
+func instructionAccounts(instruction solana.CompiledInstruction, message *solana.Message) []solana.PublicKey {
+	accounts := make([]solana.PublicKey, 0, len(instruction.Accounts))
+	for _, accountIndex := range instruction.Accounts {
+		if int(accountIndex) >= len(message.AccountKeys) {
+			break
+		}
+		accounts = append(accounts, message.AccountKeys[accountIndex])
+	}
+	return accounts
+}
+
+// launchpadTradeAccounts picks the mints, pool and trader of a Launchpad buy or sell by position.
+// Instructions without a known layout (legacy or unrecognised discriminators) use the buy/sell layout.
+func launchpadTradeAccounts(name, tradeType string, accounts []solana.PublicKey, signer solana.PublicKey) (tokenIn, tokenOut, pool, trader solana.PublicKey) {
+	layout, ok := LaunchpadAccountLayouts[name]
+	if !ok {
+		layout = launchpadTradeLayout
+	}
+	named := layout.Resolve(accounts)
+
+	baseMint := named["base_token_mint"]
+	quoteMint := named["quote_token_mint"]
+	if quoteMint.IsZero() {
+		quoteMint = solana.SolMint // Launchpad pools are quoted in SOL unless told otherwise
+	}
+
+	trader = named["payer"]
+	if trader.IsZero() {
+		trader = signer
+	}
+
+	if tradeType == "buy" {
+		return quoteMint, baseMint, named["pool_state"], trader
+	}
+	return baseMint, quoteMint, named["pool_state"], trader
+}
+
+// launchpadCreateAccounts picks the token mint, pool and creator of a Launchpad initialize by position
+func launchpadCreateAccounts(name string, accounts []solana.PublicKey, signer solana.PublicKey) (tokenMint, pool, creator solana.PublicKey) {
+	layout, ok := LaunchpadAccountLayouts[name]
+	if !ok {
+		layout = launchpadInitializeLayout
+	}
+	named := layout.Resolve(accounts)
+
+	creator = named["creator"]
+	if creator.IsZero() {
+		creator = signer
+	}
+	return named["base_mint"], named["pool_state"], creator
+}
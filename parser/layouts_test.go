@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestLayoutsMatchIDL(t *testing.T) {
+	programs := []struct {
+		instructions *DiscriminatorRegistry
+		layouts      map[string]AccountLayout
+	}{
+		{LaunchpadInstructions, LaunchpadAccountLayouts},
+		{CpmmInstructions, CpmmAccountLayouts},
+		{ClmmInstructions, ClmmAccountLayouts},
+	}
+
+	for _, program := range programs {
+		idl, ok := GetIDL(program.instructions.ProgramID)
+		if !ok {
+			t.Errorf("No IDL registered for %s", program.instructions.ProgramID)
+			continue
+		}
+
+		for name, layout := range program.layouts {
+			disc, _ := program.instructions.Discriminator(name)
+			instruction, ok := idl.Instruction(disc[:])
+			if !ok {
+				t.Errorf("%s IDL has no %s instruction", idl.Name, name)
+				continue
+			}
+			names := instruction.AccountNames()
+			if len(names) != len(layout) {
+				t.Errorf("%s: layout has %d accounts, IDL has %d", name, len(layout), len(names))
+				continue
+			}
+			for i := range names {
+				if names[i] != layout[i] {
+					t.Errorf("%s: account %d is %s in the layout, %s in the IDL", name, i, layout[i], names[i])
+				}
+			}
+		}
+	}
+}
+
+func TestLegacyBuyResolvesAccountsByPosition(t *testing.T) {
+	// Single-byte opcode data isn't Anchor, so the accounts are taken by position
+	keys := testKeys(16, solana.NewWallet().PublicKey(), RaydiumLaunchpadV1ProgramID)
+	instruction := solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: append([]uint16{0}, accountRange(2, 16)...),
+		Data: appendU64s([]byte{INSTRUCTION_BUY}, 200000000, 250000000)}
+
+	result := &Transaction{}
+	if err := defaultParser().parseBuyInstructionStandard(instruction, &solana.Message{AccountKeys: keys}, 0, legacyInstructionName(INSTRUCTION_BUY), result); err != nil {
+		t.Fatalf("Failed to parse buy: %v", err)
+	}
+	if len(result.Trade) != 1 {
+		t.Fatalf("Expected 1 trade, got %d", len(result.Trade))
+	}
+
+	// pool_state, base_token_mint and quote_token_mint are instruction accounts 4, 9 and 10
+	trade := result.Trade[0]
+	if !trade.Pool.Equals(keys[5]) || !trade.TokenOut.Equals(keys[10]) || !trade.TokenIn.Equals(keys[11]) {
+		t.Errorf("Unexpected accounts: pool %s, token in %s, token out %s", trade.Pool, trade.TokenIn, trade.TokenOut)
+	}
+	if !trade.Trader.Equals(keys[0]) || trade.AmountIn != 200000000 || trade.MaxAmountIn != 250000000 {
+		t.Errorf("Unexpected trade: %+v", trade)
+	}
+
+	encoded, _ := buildLaunchpadTradeTx(t, LaunchpadBuyExactIn, 200000000, 1000)
+	if trade := mustParse(t, encoded, 1700000000, nil).Trade; len(trade) != 1 || trade[0].Timestamp != 1700000000 {
+		t.Errorf("Expected the block time on the trade, got %+v", trade)
+	}
+}
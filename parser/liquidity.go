@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+// liquiditySide is one token of a pool along with the amount that moved in or out of its vault
+type liquiditySide struct {
+	Mint   solana.PublicKey
+	Amount uint64
+}
+
+// baseAndQuote orders the two sides of a pool so the quote is the one that is a base currency
+func (p *Parser) baseAndQuote(first, second liquiditySide) (base, quote liquiditySide) {
+	if p.isBaseCurrency(first.Mint) && !p.isBaseCurrency(second.Mint) {
+		return second, first
+	}
+	return first, second
+}
+
+// recordLiquidityAdd appends a deposit into a pool
+func (p *Parser) recordLiquidityAdd(result *Transaction, add LiquidityAdd, first, second liquiditySide) {
+	base, quote := p.baseAndQuote(first, second)
+	add.BaseMint, add.BaseAmount = base.Mint, base.Amount
+	add.QuoteMint, add.QuoteAmount = quote.Mint, quote.Amount
+	result.LiquidityAdds = append(result.LiquidityAdds, add)
+}
+
+// recordLiquidityRemove appends a withdrawal from a pool
+func (p *Parser) recordLiquidityRemove(result *Transaction, remove LiquidityRemove, first, second liquiditySide) {
+	base, quote := p.baseAndQuote(first, second)
+	remove.BaseMint, remove.BaseAmount = base.Mint, base.Amount
+	remove.QuoteMint, remove.QuoteAmount = quote.Mint, quote.Amount
+	result.LiquidityRemoves = append(result.LiquidityRemoves, remove)
+}
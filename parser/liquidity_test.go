@@ -0,0 +1,167 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestCpmmWithdrawLiquidity(t *testing.T) {
+	keys := testKeys(15, solana.NewWallet().PublicKey(), RaydiumCpSwapProgramID)
+	keys[11] = solana.SolMint // vault_0_mint
+	owner, pool, tokenMint, lpMint := keys[0], keys[3], keys[12], keys[13]
+
+	data := instructionData(CpmmInstructions, CpmmWithdraw, 5000000, 0, 0)
+
+	// Accounts in layout order: owner, then keys 2 to 14 (token_0_vault 7, token_1_vault 8)
+	accounts := append([]uint16{0}, accountRange(2, 15)...)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+
+	meta := &TransactionMeta{
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 7, Mint: solana.SolMint, Owner: pool, Amount: 85000000000},
+			{AccountIndex: 8, Mint: tokenMint, Owner: pool, Amount: 206900000000000},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 7, Mint: solana.SolMint, Owner: pool, Amount: 1000000000},
+			{AccountIndex: 8, Mint: tokenMint, Owner: pool, Amount: 2434000000000},
+		},
+	}
+	result := mustParse(t, encoded, 1700000000, meta)
+
+	if len(result.LiquidityRemoves) != 1 || len(result.LiquidityAdds) != 0 {
+		t.Fatalf("Expected 1 liquidity removal, got %d removals and %d adds", len(result.LiquidityRemoves), len(result.LiquidityAdds))
+	}
+	remove := result.LiquidityRemoves[0]
+	if !remove.Pool.Equals(pool) || !remove.Provider.Equals(owner) || !remove.LpMint.Equals(lpMint) {
+		t.Errorf("Unexpected accounts: %+v", remove)
+	}
+	if !remove.BaseMint.Equals(tokenMint) || !remove.QuoteMint.Equals(solana.SolMint) {
+		t.Errorf("Expected the token as base and SOL as quote, got %s and %s", remove.BaseMint, remove.QuoteMint)
+	}
+	if remove.BaseAmount != 204466000000000 || remove.QuoteAmount != 84000000000 || remove.LpBurned != 5000000 {
+		t.Errorf("Unexpected amounts: %+v", remove)
+	}
+	if remove.Timestamp != 1700000000 {
+		t.Errorf("Expected timestamp 1700000000, got %d", remove.Timestamp)
+	}
+}
+
+func TestAmmV4DepositLiquidity(t *testing.T) {
+	keys := testKeys(15, solana.NewWallet().PublicKey(), RaydiumV4ProgramID)
+	owner, amm, lpMint := keys[0], keys[3], keys[7]
+	tokenMint := solana.NewWallet().PublicKey()
+
+	data := appendU64s([]byte{AMM_V4_DEPOSIT}, 1000000000, 2000000000, 0)
+
+	// Accounts in layout order: keys 2 to 13 (coin vault 8, pc vault 9, user LP 13), owner, then key 14
+	accounts := append(accountRange(2, 14), 0, 14)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+
+	meta := &TransactionMeta{
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 8, Mint: tokenMint, Owner: amm, Amount: 10000000000},
+			{AccountIndex: 9, Mint: solana.SolMint, Owner: amm, Amount: 20000000000},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 8, Mint: tokenMint, Owner: amm, Amount: 11000000000},
+			{AccountIndex: 9, Mint: solana.SolMint, Owner: amm, Amount: 21900000000},
+			{AccountIndex: 13, Mint: lpMint, Owner: owner, Amount: 450000000},
+		},
+	}
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.LiquidityAdds) != 1 {
+		t.Fatalf("Expected 1 liquidity add, got %d", len(result.LiquidityAdds))
+	}
+	add := result.LiquidityAdds[0]
+	if !add.Pool.Equals(amm) || !add.Provider.Equals(owner) || !add.LpMint.Equals(lpMint) {
+		t.Errorf("Unexpected accounts: %+v", add)
+	}
+	if add.BaseAmount != 1000000000 || add.QuoteAmount != 1900000000 || add.LpMinted != 450000000 {
+		t.Errorf("Unexpected amounts: %+v", add)
+	}
+}
+
+func TestClmmOpenPosition(t *testing.T) {
+	keys := testKeys(20, solana.NewWallet().PublicKey(), RaydiumClmmProgramID)
+	payer, positionMint, pool := keys[0], keys[3], keys[6]
+	tokenMint := solana.NewWallet().PublicKey()
+
+	// ticks -100 to 200 with their tick array starts, liquidity (u128), then the amount maximums
+	disc, _ := ClmmInstructions.Discriminator(ClmmOpenPosition)
+	data := append([]byte{}, disc[:]...)
+	for _, tick := range []int32{-100, 200, -3600, 0} {
+		data = binary.LittleEndian.AppendUint32(data, uint32(tick))
+	}
+	data = appendU64s(data, 1000000, 0, 2000000000, 5000000000)
+
+	// Accounts in layout order: payer, then keys 2 to 19 (token_vault_0 13, token_vault_1 14)
+	accounts := append([]uint16{0}, accountRange(2, 20)...)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+
+	meta := &TransactionMeta{
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 13, Mint: solana.SolMint, Owner: pool, Amount: 10000000000},
+			{AccountIndex: 14, Mint: tokenMint, Owner: pool, Amount: 30000000000},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 13, Mint: solana.SolMint, Owner: pool, Amount: 11500000000},
+			{AccountIndex: 14, Mint: tokenMint, Owner: pool, Amount: 34000000000},
+		},
+	}
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.LiquidityAdds) != 1 {
+		t.Fatalf("Expected 1 liquidity add, got %d", len(result.LiquidityAdds))
+	}
+	add := result.LiquidityAdds[0]
+	if !add.Pool.Equals(pool) || !add.Provider.Equals(payer) || !add.PositionMint.Equals(positionMint) {
+		t.Errorf("Unexpected accounts: %+v", add)
+	}
+	if add.TickLower != -100 || add.TickUpper != 200 {
+		t.Errorf("Expected ticks [-100, 200], got [%d, %d]", add.TickLower, add.TickUpper)
+	}
+	if !add.BaseMint.Equals(tokenMint) || add.BaseAmount != 4000000000 || add.QuoteAmount != 1500000000 {
+		t.Errorf("Unexpected amounts: %+v", add)
+	}
+}
+
+func TestClmmDecreaseLiquidity(t *testing.T) {
+	keys := testKeys(14, solana.NewWallet().PublicKey(), RaydiumClmmProgramID)
+	owner, pool := keys[0], keys[4] // the NFT account is key 2
+	positionMint, tokenMint := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	// liquidity (u128), then the amount minimums
+	data := instructionData(ClmmInstructions, ClmmDecreaseLiquidity, 1000000, 0, 0, 0)
+
+	// Accounts in layout order: owner, then keys 2 to 12 (token_vault_0 6, token_vault_1 7)
+	accounts := append([]uint16{0}, accountRange(2, 13)...)
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+
+	meta := &TransactionMeta{
+		PreTokenBalances: []TokenBalance{
+			{AccountIndex: 2, Mint: positionMint, Owner: owner, Amount: 1},
+			{AccountIndex: 6, Mint: solana.SolMint, Owner: pool, Amount: 11500000000},
+			{AccountIndex: 7, Mint: tokenMint, Owner: pool, Amount: 34000000000},
+		},
+		PostTokenBalances: []TokenBalance{
+			{AccountIndex: 2, Mint: positionMint, Owner: owner, Amount: 1},
+			{AccountIndex: 6, Mint: solana.SolMint, Owner: pool, Amount: 10000000000},
+			{AccountIndex: 7, Mint: tokenMint, Owner: pool, Amount: 30000000000},
+		},
+	}
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.LiquidityRemoves) != 1 || len(result.LiquidityAdds) != 0 {
+		t.Fatalf("Expected 1 liquidity removal, got %d removals and %d adds", len(result.LiquidityRemoves), len(result.LiquidityAdds))
+	}
+	remove := result.LiquidityRemoves[0]
+	if !remove.Pool.Equals(pool) || !remove.Provider.Equals(owner) || !remove.PositionMint.Equals(positionMint) {
+		t.Errorf("Unexpected accounts: %+v", remove)
+	}
+	if !remove.BaseMint.Equals(tokenMint) || remove.BaseAmount != 4000000000 || remove.QuoteAmount != 1500000000 {
+		t.Errorf("Unexpected amounts: %+v", remove)
+	}
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// LoadedAddresses are the accounts a v0 transaction loaded from address lookup tables,
+// as reported by meta.loadedAddresses
+type LoadedAddresses struct {
+	Writable []solana.PublicKey
+	ReadOnly []solana.PublicKey
+}
+
+// LookupTableResolver returns the full contents of an address lookup table
+type LookupTableResolver interface {
+	ResolveLookupTable(table solana.PublicKey) (solana.PublicKeySlice, error)
+}
+
+// StaticLookupTables resolves lookup tables from a fixed in-memory set
+type StaticLookupTables map[solana.PublicKey]solana.PublicKeySlice
+
+// ResolveLookupTable implements LookupTableResolver
+func (s StaticLookupTables) ResolveLookupTable(table solana.PublicKey) (solana.PublicKeySlice, error) {
+	addresses, ok := s[table]
+	if !ok {
+		return nil, fmt.Errorf("unknown address lookup table %s", table)
+	}
+	return addresses, nil
+}
+
+// RPCLookupTableResolver fetches lookup tables over RPC and caches them
+type RPCLookupTableResolver struct {
+	client  *rpc.Client
+	timeout time.Duration
+
+	mu    sync.Mutex
+	cache map[solana.PublicKey]solana.PublicKeySlice
+}
+
+// NewRPCLookupTableResolver creates a resolver backed by the given RPC client
+func NewRPCLookupTableResolver(client *rpc.Client) *RPCLookupTableResolver {
+	return &RPCLookupTableResolver{
+		client:  client,
+		timeout: 10 * time.Second,
+		cache:   make(map[solana.PublicKey]solana.PublicKeySlice),
+	}
+}
+
+// ResolveLookupTable implements LookupTableResolver
+func (r *RPCLookupTableResolver) ResolveLookupTable(table solana.PublicKey) (solana.PublicKeySlice, error) {
+	r.mu.Lock()
+	addresses, ok := r.cache[table]
+	r.mu.Unlock()
+	if ok {
+		return addresses, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	state, err := addresslookuptable.GetAddressLookupTable(ctx, r.client, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch address lookup table %s: %w", table, err)
+	}
+
+	// Tables only ever grow, so a cached copy stays valid for the indexes it covers
+	r.mu.Lock()
+	r.cache[table] = state.Addresses
+	r.mu.Unlock()
+
+	return state.Addresses, nil
+}
+
+// SetLookupTableResolver sets the resolver the default parser uses for v0 transactions parsed without loaded addresses
+func SetLookupTableResolver(resolver LookupTableResolver) {
+	configureDefaultParser(WithLookupTableResolver(resolver))
+}
+
+// resolveMessageAccounts appends the lookup table accounts of a v0 message to its AccountKeys,
+// so that instruction account indexes can be used directly. Loaded addresses from the transaction
+// meta are preferred; the lookup table resolver is only consulted when they are missing.
+func (p *Parser) resolveMessageAccounts(message *solana.Message, meta *TransactionMeta) error {
+	if !message.IsVersioned() || len(message.AddressTableLookups) == 0 || message.IsResolved() {
+		return nil
+	}
+
+	var tables map[solana.PublicKey]solana.PublicKeySlice
+	var err error
+
+	switch {
+	case meta != nil && len(meta.LoadedAddresses.Writable)+len(meta.LoadedAddresses.ReadOnly) > 0:
+		tables, err = tablesFromLoadedAddresses(message.AddressTableLookups, meta.LoadedAddresses)
+	case p.resolver != nil:
+		tables, err = tablesFromResolver(message.AddressTableLookups, p.resolver)
+	default:
+		return fmt.Errorf("v0 transaction uses %d address lookup tables but no loaded addresses or resolver are available",
+			len(message.AddressTableLookups))
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := message.SetAddressTables(tables); err != nil {
+		return err
+	}
+	return message.ResolveLookups()
+}
+
+// tablesFromLoadedAddresses rebuilds the referenced slots of each lookup table from the
+// flattened loaded addresses, which list all writable entries first, then all readonly ones
+func tablesFromLoadedAddresses(lookups solana.MessageAddressTableLookupSlice, loaded LoadedAddresses) (map[solana.PublicKey]solana.PublicKeySlice, error) {
+	if len(loaded.Writable) != lookups.NumWritableLookups() || len(loaded.Writable)+len(loaded.ReadOnly) != lookups.NumLookups() {
+		return nil, fmt.Errorf("loaded addresses (%d writable, %d readonly) don't match the message lookups (%d writable, %d total)",
+			len(loaded.Writable), len(loaded.ReadOnly), lookups.NumWritableLookups(), lookups.NumLookups())
+	}
+
+	tables := make(map[solana.PublicKey]solana.PublicKeySlice, len(lookups))
+	writable, readonly := loaded.Writable, loaded.ReadOnly
+
+	for _, lookup := range lookups {
+		table := tables[lookup.AccountKey]
+		for _, idx := range lookup.WritableIndexes {
+			table = setTableEntry(table, idx, writable[0])
+			writable = writable[1:]
+		}
+		for _, idx := range lookup.ReadonlyIndexes {
+			table = setTableEntry(table, idx, readonly[0])
+			readonly = readonly[1:]
+		}
+		tables[lookup.AccountKey] = table
+	}
+
+	return tables, nil
+}
+
+func setTableEntry(table solana.PublicKeySlice, idx uint8, address solana.PublicKey) solana.PublicKeySlice {
+	for len(table) <= int(idx) {
+		table = append(table, solana.PublicKey{})
+	}
+	table[idx] = address
+	return table
+}
+
+func tablesFromResolver(lookups solana.MessageAddressTableLookupSlice, resolver LookupTableResolver) (map[solana.PublicKey]solana.PublicKeySlice, error) {
+	tables := make(map[solana.PublicKey]solana.PublicKeySlice, len(lookups))
+	for _, tableID := range lookups.GetTableIDs() {
+		addresses, err := resolver.ResolveLookupTable(tableID)
+		if err != nil {
+			return nil, err
+		}
+		tables[tableID] = addresses
+	}
+	return tables, nil
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// buildV0LaunchpadBuy builds a v0 buy_exact_in transaction whose accounts, apart from the payer
+// and the program, all come from a single address lookup table
+func buildV0LaunchpadBuy(t *testing.T, table solana.PublicKey) (string, []solana.PublicKey) {
+	data := instructionData(LaunchpadInstructions, LaunchpadBuyExactIn, 250000000, 1, 0)
+	accounts := append(append([]uint16{0}, accountRange(2, 15)...), 1)
+
+	payer := solana.NewWallet().PublicKey()
+	message := solana.Message{
+		Header: solana.MessageHeader{
+			NumRequiredSignatures:       1,
+			NumReadonlyUnsignedAccounts: 1,
+		},
+		AccountKeys:     solana.PublicKeySlice{payer, RaydiumLaunchpadV1ProgramID},
+		RecentBlockhash: solana.Hash{},
+		Instructions: []solana.CompiledInstruction{{
+			ProgramIDIndex: 1,
+			Accounts:       accounts,
+			Data:           data,
+		}},
+		AddressTableLookups: solana.MessageAddressTableLookupSlice{{
+			AccountKey:      table,
+			WritableIndexes: []uint8{0, 1, 2, 3, 4},
+			ReadonlyIndexes: []uint8{5, 6, 7, 8, 9, 10, 11, 12},
+		}},
+	}
+	message.SetVersion(solana.MessageVersionV0)
+
+	return encodeMessage(t, message), testKeys(13)
+}
+
+func TestV0TransactionWithLoadedAddresses(t *testing.T) {
+	table := solana.NewWallet().PublicKey()
+	encoded, tableAddresses := buildV0LaunchpadBuy(t, table)
+
+	meta := &TransactionMeta{
+		LoadedAddresses: LoadedAddresses{
+			Writable: tableAddresses[:5],
+			ReadOnly: tableAddresses[5:],
+		},
+	}
+
+	result := mustParse(t, encoded, 0, meta)
+	if len(result.Trade) != 1 {
+		t.Fatalf("Expected 1 trade from lookup table accounts, got %d", len(result.Trade))
+	}
+
+	// pool_state is the 5th instruction account, base_token_mint the 10th
+	trade := result.Trade[0]
+	if !trade.Pool.Equals(tableAddresses[3]) {
+		t.Errorf("Expected pool %s, got %s", tableAddresses[3], trade.Pool)
+	}
+	if !trade.TokenOut.Equals(tableAddresses[8]) {
+		t.Errorf("Expected token out %s, got %s", tableAddresses[8], trade.TokenOut)
+	}
+}
+
+func TestV0TransactionWithLookupTableResolver(t *testing.T) {
+	table := solana.NewWallet().PublicKey()
+	encoded, tableAddresses := buildV0LaunchpadBuy(t, table)
+
+	SetLookupTableResolver(StaticLookupTables{table: tableAddresses})
+	defer SetLookupTableResolver(nil)
+
+	result := mustParse(t, encoded, 0, nil)
+	if len(result.Trade) != 1 || !result.Trade[0].Pool.Equals(tableAddresses[3]) {
+		t.Errorf("Expected lookup table accounts to be resolved through the resolver, got %+v", result.Trade)
+	}
+}
+
+func TestV0TransactionWithoutLookupData(t *testing.T) {
+	encoded, _ := buildV0LaunchpadBuy(t, solana.NewWallet().PublicKey())
+
+	result := mustParse(t, encoded, 0, nil)
+	if len(result.Trade) != 0 {
+		t.Errorf("Expected no trades when lookup tables can't be resolved, got %d", len(result.Trade))
+	}
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// InnerInstructionSet holds the instructions invoked (via CPI) while executing one top-level instruction
+type InnerInstructionSet struct {
+	Index        int // Index of the top-level instruction
+	Instructions []InnerInstruction
+}
+
+// InnerInstruction is a single CPI instruction in the order it was invoked
+type InnerInstruction struct {
+	Instruction solana.CompiledInstruction
+	StackHeight int // 2 for instructions invoked by a top-level instruction; 0 if the node didn't report it
+}
+
+// TransactionMetaFromRPC converts the meta returned by getTransaction into a TransactionMeta.
+// rpc.TransactionMeta doesn't carry inner instruction stack heights; use TransactionMetaFromJSON
+// on the raw meta to keep them.
+func TransactionMetaFromRPC(meta *rpc.TransactionMeta) *TransactionMeta {
+	if meta == nil {
+		return nil
+	}
+
+	result := &TransactionMeta{
+		Err:               transactionErrorFromRPC(meta.Err),
+		Fee:               meta.Fee,
+		PreBalances:       meta.PreBalances,
+		PostBalances:      meta.PostBalances,
+		PreTokenBalances:  tokenBalancesFromRPC(meta.PreTokenBalances),
+		PostTokenBalances: tokenBalancesFromRPC(meta.PostTokenBalances),
+		LogMessages:       meta.LogMessages,
+		LoadedAddresses: LoadedAddresses{
+			Writable: meta.LoadedAddresses.Writable,
+			ReadOnly: meta.LoadedAddresses.ReadOnly,
+		},
+	}
+
+	for _, set := range meta.InnerInstructions {
+		innerSet := InnerInstructionSet{Index: int(set.Index)}
+		for _, instruction := range set.Instructions {
+			innerSet.Instructions = append(innerSet.Instructions, InnerInstruction{Instruction: instruction})
+		}
+		result.InnerInstructions = append(result.InnerInstructions, innerSet)
+	}
+
+	if meta.ComputeUnitsConsumed != nil {
+		result.ComputeUnitsConsumed = *meta.ComputeUnitsConsumed
+	}
+
+	return result
+}
+
+// transactionErrorFromRPC decodes the err field of an rpc.TransactionMeta, which holds the generic JSON value
+func transactionErrorFromRPC(err interface{}) *TransactionError {
+	if err == nil {
+		return nil
+	}
+	raw, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		return &TransactionError{Kind: "Unknown", InstructionIndex: -1}
+	}
+	return transactionErrorFromJSON(raw)
+}
+
+func tokenBalancesFromRPC(balances []rpc.TokenBalance) []TokenBalance {
+	var result []TokenBalance
+	for _, balance := range balances {
+		tokenBalance := TokenBalance{
+			AccountIndex: int(balance.AccountIndex),
+			Mint:         balance.Mint,
+		}
+		if balance.Owner != nil {
+			tokenBalance.Owner = *balance.Owner
+		}
+		if balance.UiTokenAmount != nil {
+			tokenBalance.Amount, _ = strconv.ParseUint(balance.UiTokenAmount.Amount, 10, 64)
+			tokenBalance.Decimals = balance.UiTokenAmount.Decimals
+		}
+		result = append(result, tokenBalance)
+	}
+	return result
+}
+
+// TransactionMetaFromJSON converts the raw JSON meta of a getTransaction response into a TransactionMeta
+func TransactionMetaFromJSON(data []byte) (*TransactionMeta, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var meta rpc.TransactionMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse transaction meta: %w", err)
+	}
+
+	// Stack heights are only present in the raw JSON
+	var stackHeights struct {
+		InnerInstructions []struct {
+			Instructions []struct {
+				StackHeight *int `json:"stackHeight"`
+			} `json:"instructions"`
+		} `json:"innerInstructions"`
+	}
+	if err := json.Unmarshal(data, &stackHeights); err != nil {
+		return nil, fmt.Errorf("failed to parse inner instruction stack heights: %w", err)
+	}
+
+	result := TransactionMetaFromRPC(&meta)
+	for i, set := range stackHeights.InnerInstructions {
+		if i >= len(result.InnerInstructions) {
+			break
+		}
+		for j, instruction := range set.Instructions {
+			if j < len(result.InnerInstructions[i].Instructions) && instruction.StackHeight != nil {
+				result.InnerInstructions[i].Instructions[j].StackHeight = *instruction.StackHeight
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestTransactionMetaFromJSONStackHeight(t *testing.T) {
+	raw := `{
+		"err": null,
+		"fee": 5000,
+		"preBalances": [100, 0],
+		"postBalances": [95, 0],
+		"innerInstructions": [{
+			"index": 1,
+			"instructions": [
+				{"programIdIndex": 2, "accounts": [0, 1], "data": "3Bxs4h24hBtQy9rw", "stackHeight": 2},
+				{"programIdIndex": 3, "accounts": [1], "data": "3Bxs4h24hBtQy9rw", "stackHeight": 3}
+			]
+		}],
+		"loadedAddresses": {"writable": [], "readonly": []}
+	}`
+
+	meta, err := TransactionMetaFromJSON([]byte(raw))
+	if err != nil {
+		t.Fatalf("Failed to parse meta: %v", err)
+	}
+	if len(meta.InnerInstructions) != 1 || meta.InnerInstructions[0].Index != 1 {
+		t.Fatalf("Expected one inner instruction set for instruction 1, got %+v", meta.InnerInstructions)
+	}
+
+	inner := meta.InnerInstructions[0].Instructions
+	if len(inner) != 2 || inner[0].StackHeight != 2 || inner[1].StackHeight != 3 {
+		t.Errorf("Expected stack heights 2 and 3, got %+v", inner)
+	}
+	if inner[1].Instruction.ProgramIDIndex != 3 {
+		t.Errorf("Expected program index 3, got %d", inner[1].Instruction.ProgramIDIndex)
+	}
+}
+
+func TestInnerLaunchpadBuyFromRouter(t *testing.T) {
+	router := solana.NewWallet().PublicKey()
+	keys := testKeys(17, solana.NewWallet().PublicKey(), router, RaydiumLaunchpadV1ProgramID)
+	encoded := encodeTransaction(t, keys, 2, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: []uint16{0, 2}, Data: []byte{0x01}})
+
+	data := instructionData(LaunchpadInstructions, LaunchpadBuyExactIn, 50000000, 1, 0)
+	buyAccounts := append([]uint16{0}, accountRange(3, 17)...)
+
+	meta := &TransactionMeta{
+		InnerInstructions: []InnerInstructionSet{{
+			Index: 0,
+			Instructions: []InnerInstruction{
+				{Instruction: solana.CompiledInstruction{ProgramIDIndex: 2, Accounts: buyAccounts, Data: data}, StackHeight: 2},
+				// emit_cpi event of the buy must not produce a second trade
+				{Instruction: solana.CompiledInstruction{ProgramIDIndex: 2, Accounts: []uint16{15}, Data: append(AnchorEventCPITag[:], make([]byte, 40)...)}, StackHeight: 3},
+			},
+		}},
+	}
+
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.Trade) != 1 {
+		t.Fatalf("Expected 1 inner trade, got %d", len(result.Trade))
+	}
+	trade := result.Trade[0]
+	if trade.InstructionIndex != 0 || trade.InnerIndex != 0 || trade.StackHeight != 2 {
+		t.Errorf("Expected trade at 0.0 with stack height 2, got %d.%d height %d", trade.InstructionIndex, trade.InnerIndex, trade.StackHeight)
+	}
+	if trade.AmountIn != 50000000 || trade.InstructionName != LaunchpadBuyExactIn {
+		t.Errorf("Unexpected trade: %+v", trade)
+	}
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// parseLaunchpadMigrateInstruction decodes a Launchpad graduation (migrate_to_amm or migrate_to_cpswap)
+// into a Migration. The amounts are what the new pool's vaults received, falling back to what left the
+// bonding curve's vaults. migrate_to_cpswap locks the LP through the LP lock program, which shows as the
+// lock vault's balance; the LP burn of migrate_to_amm is picked up by applyMigrationLiquidity.
+func parseLaunchpadMigrateInstruction(name string, accounts []solana.PublicKey, index int, signer solana.PublicKey, tokens tokenAccounts, result *Transaction) error {
+	layout := LaunchpadAccountLayouts[name]
+	if len(accounts) < len(layout) {
+		return fmt.Errorf("%w for Launchpad %s: %d of %d", ErrInsufficientAccounts, name, len(accounts), len(layout))
+	}
+	named := layout.Resolve(accounts)
+
+	owner := named["payer"]
+	if owner.IsZero() {
+		owner = signer
+	}
+	migration := Migration{
+		FromPool:        named["pool_state"],
+		Token:           named["base_mint"],
+		QuoteMint:       named["quote_mint"],
+		Owner:           owner,
+		InstructionName: name,
+	}
+
+	var baseVault, quoteVault solana.PublicKey
+	switch name {
+	case LaunchpadMigrateToAmm:
+		migration.ToPool, migration.ToProgram = named["amm_pool"], RaydiumV4ProgramID
+		migration.LpMint = named["amm_lp_mint"]
+		baseVault, quoteVault = named["amm_base_vault"], named["amm_quote_vault"]
+		migration.LpAmount = tokens.inflow(named["pool_lp_token"])
+	case LaunchpadMigrateToCpswap:
+		migration.ToPool, migration.ToProgram = named["cpswap_pool"], RaydiumCpSwapProgramID
+		migration.LpMint = named["cpswap_lp_mint"]
+		baseVault, quoteVault = named["cpswap_base_vault"], named["cpswap_quote_vault"]
+		if locked := tokens.inflow(named["lock_lp_vault"]); locked > 0 {
+			migration.LpAmount, migration.LpLocked = locked, true
+		}
+	default:
+		return fmt.Errorf("not a Launchpad migration: %s", name)
+	}
+
+	migration.Amount = tokens.inflow(baseVault)
+	if migration.Amount == 0 {
+		migration.Amount = tokens.outflow(named["base_vault"])
+	}
+	migration.QuoteAmount = tokens.inflow(quoteVault)
+	if migration.QuoteAmount == 0 {
+		migration.QuoteAmount = tokens.outflow(named["quote_vault"])
+	}
+
+	result.Migrate = append(result.Migrate, migration)
+	return nil
+}
+
+// applyMigrationLiquidity marks the migrations whose LP tokens were burned in the same transaction,
+// taking the LP amount from the burn
+func applyMigrationLiquidity(result *Transaction) {
+	for i := range result.Migrate {
+		migration := &result.Migrate[i]
+		if migration.LpMint.IsZero() || migration.LpLocked {
+			continue
+		}
+
+		var burned uint64
+		for _, transfer := range result.TokenTransfers {
+			if transfer.Mint.Equals(migration.LpMint) && (transfer.Kind == "burn" || transfer.Kind == "burn_checked") {
+				burned += transfer.Amount
+			}
+		}
+		if burned > 0 {
+			migration.LpAmount, migration.LpBurned = burned, true
+		}
+	}
+}
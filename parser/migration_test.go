@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// launchpadMigrateTransaction encodes a single Launchpad migration whose accounts are the payer
+// followed by account keys 2 onwards, so layout position i is key i+1
+func launchpadMigrateTransaction(t *testing.T, name string, keys solana.PublicKeySlice, inner []solana.CompiledInstruction) (string, *TransactionMeta) {
+	accounts := append([]uint16{0}, accountRange(2, uint16(len(LaunchpadAccountLayouts[name])+1))...)
+
+	data := instructionData(LaunchpadInstructions, name)
+	if name == LaunchpadMigrateToAmm {
+		data = append(appendU64s(data, 1, 1), 0)
+	}
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data})
+
+	meta := &TransactionMeta{}
+	if len(inner) > 0 {
+		set := InnerInstructionSet{Index: 0}
+		for _, instruction := range inner {
+			set.Instructions = append(set.Instructions, InnerInstruction{Instruction: instruction, StackHeight: 2})
+		}
+		meta.InnerInstructions = []InnerInstructionSet{set}
+	}
+	return encoded, meta
+}
+
+func TestLaunchpadMigrateToCpswap(t *testing.T) {
+	keys := testKeys(29, solana.NewWallet().PublicKey(), RaydiumLaunchpadV1ProgramID)
+	keys[3] = solana.SolMint
+	payer, baseMint, cpswapPool, lpMint, launchpadPool := keys[0], keys[2], keys[6], keys[8], keys[18]
+
+	encoded, meta := launchpadMigrateTransaction(t, LaunchpadMigrateToCpswap, keys, nil)
+	meta.PostTokenBalances = []TokenBalance{
+		{AccountIndex: 9, Mint: baseMint, Amount: 206900000000000},    // cpswap_base_vault
+		{AccountIndex: 10, Mint: solana.SolMint, Amount: 79000000000}, // cpswap_quote_vault
+		{AccountIndex: 16, Mint: lpMint, Amount: 4000000000000},       // lock_lp_vault
+	}
+
+	result := mustParse(t, encoded, 0, meta)
+	if len(result.Migrate) != 1 {
+		t.Fatalf("Expected 1 migration, got %d", len(result.Migrate))
+	}
+
+	migration := result.Migrate[0]
+	if !migration.FromPool.Equals(launchpadPool) || !migration.ToPool.Equals(cpswapPool) || !migration.ToProgram.Equals(RaydiumCpSwapProgramID) {
+		t.Errorf("Unexpected pools: %+v", migration)
+	}
+	if !migration.Token.Equals(baseMint) || !migration.QuoteMint.Equals(solana.SolMint) || !migration.Owner.Equals(payer) {
+		t.Errorf("Unexpected mints or owner: %+v", migration)
+	}
+	if migration.Amount != 206900000000000 || migration.QuoteAmount != 79000000000 {
+		t.Errorf("Expected 206900000000000 base and 79000000000 quote, got %d and %d", migration.Amount, migration.QuoteAmount)
+	}
+	if !migration.LpMint.Equals(lpMint) || migration.LpAmount != 4000000000000 || !migration.LpLocked || migration.LpBurned {
+		t.Errorf("Expected the LP to be locked: %+v", migration)
+	}
+}
+
+func TestLaunchpadMigrateToAmmBurnsLp(t *testing.T) {
+	keys := append(testKeys(33, solana.NewWallet().PublicKey(), RaydiumLaunchpadV1ProgramID), TokenProgramID)
+	ammPool, lpMint, authority, poolLpToken := keys[14], keys[17], keys[23], keys[28]
+
+	// The Launchpad burns the LP it received from the new pool
+	burn := appendU64s([]byte{TOKEN_INSTRUCTION_BURN}, 1500000000)
+	encoded, meta := launchpadMigrateTransaction(t, LaunchpadMigrateToAmm, keys, []solana.CompiledInstruction{
+		{ProgramIDIndex: 33, Accounts: []uint16{28, 17, 23}, Data: burn},
+	})
+
+	result := mustParse(t, encoded, 0, meta)
+	if len(result.Migrate) != 1 {
+		t.Fatalf("Expected 1 migration, got %d", len(result.Migrate))
+	}
+
+	migration := result.Migrate[0]
+	if !migration.ToPool.Equals(ammPool) || !migration.ToProgram.Equals(RaydiumV4ProgramID) || !migration.LpMint.Equals(lpMint) {
+		t.Errorf("Unexpected accounts: %+v", migration)
+	}
+	if !migration.LpBurned || migration.LpLocked || migration.LpAmount != 1500000000 {
+		t.Errorf("Expected 1500000000 LP burned: %+v", migration)
+	}
+	if len(result.TokenTransfers) != 1 || result.TokenTransfers[0].Kind != "burn" ||
+		!result.TokenTransfers[0].Source.Equals(poolLpToken) || !result.TokenTransfers[0].Authority.Equals(authority) {
+		t.Errorf("Expected the LP burn among the token transfers, got %+v", result.TokenTransfers)
+	}
+}
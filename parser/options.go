@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"io"
+	"log"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Logger receives the parser's progress and debug messages; *log.Logger satisfies it
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// Parser parses Raydium transactions. Build one with New; it's safe for concurrent use as long as
+// the registries it was given aren't modified while it parses.
+type Parser struct {
+	tokens     TokenRegistry
+	logger     Logger
+	decoders   *DecoderRegistry
+	mode       ParseMode
+	resolver   LookupTableResolver
+	platforms  PlatformRegistry
+	failedMode FailedTransactionMode
+	idls       IDLRegistry
+	debug      func(*ComprehensiveInstructionDebug)
+}
+
+// Option configures a Parser
+type Option func(*Parser)
+
+// New returns a strict parser with the default tokens, decoders and platforms that logs nothing
+func New(options ...Option) *Parser {
+	p := &Parser{
+		tokens:     DefaultTokens,
+		logger:     log.New(io.Discard, "", 0),
+		decoders:   NewDecoderRegistry(DefaultProgramDecoders()...),
+		mode:       StrictParsing,
+		platforms:  DefaultPlatforms,
+		failedMode: FlagFailedTransactions,
+		idls:       DefaultIDLs,
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// WithTokenRegistry sets the known tokens; those marked Quote decide whether a trade is a buy or a sell
+func WithTokenRegistry(tokens TokenRegistry) Option {
+	return func(p *Parser) { p.tokens = tokens }
+}
+
+// WithLogger sets where messages go; nil discards them
+func WithLogger(logger Logger) Option {
+	return func(p *Parser) {
+		if logger == nil {
+			logger = log.New(io.Discard, "", 0)
+		}
+		p.logger = logger
+	}
+}
+
+// WithDecoders sets the programs the parser decodes and how
+func WithDecoders(decoders *DecoderRegistry) Option {
+	return func(p *Parser) { p.decoders = decoders }
+}
+
+// WithParseMode sets how undecodable transactions and instructions are handled
+func WithParseMode(mode ParseMode) Option {
+	return func(p *Parser) { p.mode = mode }
+}
+
+// WithLookupTableResolver sets the resolver used for v0 transactions parsed without loaded addresses
+func WithLookupTableResolver(resolver LookupTableResolver) Option {
+	return func(p *Parser) { p.resolver = resolver }
+}
+
+// WithPlatformRegistry sets the registry used to label the platform of Launchpad creates and trades
+func WithPlatformRegistry(platforms PlatformRegistry) Option {
+	return func(p *Parser) { p.platforms = platforms }
+}
+
+// WithFailedTransactionMode sets how the operations of failed transactions are reported
+func WithFailedTransactionMode(mode FailedTransactionMode) Option {
+	return func(p *Parser) { p.failedMode = mode }
+}
+
+// WithInstructionDebug hands a breakdown of every instruction and its accounts to hook before the
+// instruction is decoded; nil turns it off
+func WithInstructionDebug(hook func(*ComprehensiveInstructionDebug)) Option {
+	return func(p *Parser) { p.debug = hook }
+}
+
+// WithIDL decodes the instructions of a program through idl, replacing any bundled IDL for it. The
+// Launchpad, CPMM and CLMM decoders consult IDLs; AMM v4 isn't an Anchor program and has none.
+func WithIDL(programID solana.PublicKey, idl *IDL) Option {
+	return func(p *Parser) {
+		idls := make(IDLRegistry, len(p.idls)+1)
+		for id, known := range p.idls {
+			idls[id] = known
+		}
+		idls[programID] = idl
+		p.idls = idls
+	}
+}
+
+// The default parser backs the package-level functions. The setters swap in a reconfigured copy,
+// so parses already running keep the settings they started with.
+var (
+	defaultParserMu  sync.Mutex
+	defaultParserPtr atomic.Pointer[Parser]
+)
+
+func init() {
+	defaultParserPtr.Store(New())
+}
+
+// defaultParser returns the current default parser
+func defaultParser() *Parser {
+	return defaultParserPtr.Load()
+}
+
+// configureDefaultParser replaces the default parser with a copy that has options applied
+func configureDefaultParser(options ...Option) {
+	defaultParserMu.Lock()
+	defer defaultParserMu.Unlock()
+
+	p := *defaultParserPtr.Load()
+	for _, option := range options {
+		option(&p)
+	}
+	defaultParserPtr.Store(&p)
+}
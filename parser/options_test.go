@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+type captureLogger struct {
+	lines []string
+}
+
+func (l *captureLogger) Printf(format string, v ...any) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestParserOptionsAreIndependent(t *testing.T) {
+	router := solana.NewWallet().PublicKey()
+	payer, pool := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	encoded := encodeTransaction(t, solana.PublicKeySlice{payer, pool, router}, 1,
+		solana.CompiledInstruction{ProgramIDIndex: 2, Accounts: []uint16{0, 1}, Data: []byte{1}})
+
+	decoders := NewDecoderRegistry(NewProgramDecoder(func(ctx *InstructionContext, result *Transaction) error {
+		result.Trade = append(result.Trade, TradeInfo{InstructionIndex: ctx.Index, Pool: ctx.Accounts[1], TradeType: "swap"})
+		return nil
+	}, router))
+	logger := &captureLogger{}
+	custom := New(WithDecoders(decoders), WithLogger(logger))
+
+	result, err := custom.ParseTransactionWithMeta(encoded, 1, 0, solana.Signature{}, &TransactionMeta{})
+	if err != nil {
+		t.Fatalf("Failed to parse transaction: %v", err)
+	}
+	if len(result.Trade) != 1 || !result.Trade[0].Pool.Equals(pool) {
+		t.Errorf("Expected the custom decoder's trade, got %+v", result.Trade)
+	}
+	if len(logger.lines) == 0 {
+		t.Error("Expected the parser to log through the given logger")
+	}
+
+	result, err = New(WithLogger(nil)).ParseTransactionWithMeta(encoded, 1, 0, solana.Signature{}, &TransactionMeta{})
+	if err != nil {
+		t.Fatalf("Failed to parse transaction: %v", err)
+	}
+	if len(result.Trade) != 0 {
+		t.Errorf("Expected a default parser to skip the router, got %+v", result.Trade)
+	}
+}
+
+func TestParserTokenRegistryDecidesBuysAndSells(t *testing.T) {
+	quote, token := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	trade := TradeInfo{TokenIn: quote, TokenOut: token, AmountIn: 100, AmountOut: 5, TradeType: "swap"}
+
+	result := &Transaction{Trade: []TradeInfo{trade}}
+	New().deriveTradeViews(result)
+	if len(result.SwapSells) != 1 {
+		t.Errorf("Expected a sell with the default tokens, got %d buys and %d sells", len(result.SwapBuys), len(result.SwapSells))
+	}
+
+	tokens := TokenRegistry{quote: {Mint: quote, Symbol: "QUOTE", Quote: true}}
+	result = &Transaction{Trade: []TradeInfo{trade}}
+	New(WithTokenRegistry(tokens)).deriveTradeViews(result)
+	if len(result.SwapBuys) != 1 {
+		t.Errorf("Expected a buy with the custom quote token, got %d buys and %d sells", len(result.SwapBuys), len(result.SwapSells))
+	}
+}
+
+func TestNewParserIsStrict(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0xff}, 200))
+	if _, err := New(WithLogger(nil)).ParseTransaction(encoded, 1, 0); !errors.Is(err, ErrUndecodableTransaction) {
+		t.Errorf("Expected ErrUndecodableTransaction, got %v", err)
+	}
+}
+
+func TestInstructionDebugHook(t *testing.T) {
+	encoded, _ := buildLaunchpadTradeTx(t, LaunchpadBuyExactIn, 200000000, 7000000000000)
+
+	var seen []*ComprehensiveInstructionDebug
+	p := New(WithInstructionDebug(func(info *ComprehensiveInstructionDebug) { seen = append(seen, info) }))
+	if _, err := p.ParseTransactionWithMeta(encoded, 1, 0, solana.Signature{}, &TransactionMeta{}); err != nil {
+		t.Fatalf("Failed to parse transaction: %v", err)
+	}
+	if len(seen) != 1 || seen[0].InstructionIndex != 0 || seen[0].ProgramID != RaydiumLaunchpadV1ProgramID.String() {
+		t.Errorf("Expected the hook to see the Launchpad instruction, got %+v", seen)
+	}
+	if defaultParser().debug != nil {
+		t.Error("Expected the default parser to have no debug hook")
+	}
+}
+
+func TestSettersRaceWithParsing(t *testing.T) {
+	encoded, _ := buildLaunchpadTradeTx(t, LaunchpadBuyExactIn, 200000000, 7000000000000)
+	defer SetParseMode(StrictParsing)
+	defer SetFailedTransactionMode(FlagFailedTransactions)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			SetParseMode(ParseMode(i % 2))
+			SetFailedTransactionMode(FailedTransactionMode(i % 2))
+			SetPlatformRegistry(DefaultPlatforms)
+			SetLookupTableResolver(nil)
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		if _, err := ParseTransactionWithMeta(encoded, 1, 0, solana.Signature{}, &TransactionMeta{}); err != nil {
+			t.Errorf("Failed to parse transaction: %v", err)
+		}
+	}
+	<-done
+}
@@ -0,0 +1,26 @@
+package parser
+
+// ParseMode controls what the parser does with input it can't decode
+type ParseMode int
+
+const (
+	// StrictParsing returns an error for transactions that can't be decoded and reports instructions of
+	// unknown layout as diagnostics; nothing that isn't in the transaction is ever produced
+	StrictParsing ParseMode = iota
+	// LenientParsing guesses trades and creates from the account and data lengths of unknown
+	// instructions; transactions that can't be decoded are still an error
+	LenientParsing
+	// DemoParsing is LenientParsing, except that undecodable input yields a sample 1 SOL -> 25 USDC swap.
+	// For demos only.
+	DemoParsing
+)
+
+// SetParseMode sets how the default parser handles undecodable transactions and instructions
+func SetParseMode(mode ParseMode) {
+	configureDefaultParser(WithParseMode(mode))
+}
+
+// guessUnknownInstructions reports whether instructions of unknown layout should be decoded by heuristics
+func (p *Parser) guessUnknownInstructions() bool {
+	return p.mode != StrictParsing
+}
@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestStrictModeRejectsUndecodableTransactions(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0xff}, 200))
+
+	result, err := ParseTransaction(encoded, 1, 0)
+	if !errors.Is(err, ErrUndecodableTransaction) || result != nil {
+		t.Fatalf("Expected ErrUndecodableTransaction and no result, got %v, %+v", err, result)
+	}
+
+	SetParseMode(LenientParsing)
+	defer SetParseMode(StrictParsing)
+	result, err = ParseTransaction(encoded, 1, 0)
+	if !errors.Is(err, ErrUndecodableTransaction) || result != nil {
+		t.Fatalf("Expected lenient parsing to reject the transaction too, got %v, %+v", err, result)
+	}
+
+	SetParseMode(DemoParsing)
+	result, err = ParseTransaction(encoded, 1, 0)
+	if err != nil || len(result.Trade) != 1 {
+		t.Fatalf("Expected the sample trade in demo mode, got %v, %+v", err, result)
+	}
+}
+
+func TestStrictModeDoesNotGuessUnknownInstructions(t *testing.T) {
+	keys := append(testKeys(9), RaydiumLaunchpadV1ProgramID)
+
+	data := append([]byte{1, 2, 3, 4, 5, 6, 7, 8}, make([]byte, 40)...)
+	data[8] = 1
+	encoded := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 9, Accounts: accountRange(0, 9), Data: data})
+
+	result := mustParse(t, encoded, 0, &TransactionMeta{})
+	if len(result.Create)+len(result.Trade) != 0 {
+		t.Errorf("Expected nothing guessed from an unknown instruction, got %+v %+v", result.Create, result.Trade)
+	}
+	if len(result.Diagnostics) != 1 || !errors.Is(result.Diagnostics[0], ErrUnknownDiscriminator) {
+		t.Errorf("Expected an unknown discriminator diagnostic, got %v", result.Diagnostics)
+	}
+
+	SetParseMode(LenientParsing)
+	defer SetParseMode(StrictParsing)
+	result = mustParse(t, encoded, 0, &TransactionMeta{})
+	if len(result.Create) != 1 {
+		t.Errorf("Expected lenient parsing to guess a create, got %+v", result.Create)
+	}
+}
+
+func TestStrictModeIgnoresLegacyOpcodes(t *testing.T) {
+	short, _ := launchpadTransaction(t, appendU64s([]byte{INSTRUCTION_BUY}, 1000000000, 5000))
+	zeroPrefixed, _ := launchpadTransaction(t, appendU64s(make([]byte, 8), 1000000000, 5000))
+	complexBuy := appendU64s(nil, 0x66063d1201daebea, 1000000000, 5000)
+	keys := testKeys(16, solana.NewWallet().PublicKey(), RaydiumUnknownProgramID1)
+	complex := encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: append([]uint16{0}, accountRange(2, 16)...), Data: complexBuy})
+
+	for name, encoded := range map[string]string{"opcode": short, "zero prefix": zeroPrefixed, "made-up discriminator": complex} {
+		result := mustParse(t, encoded, 0, &TransactionMeta{})
+		if len(result.Create)+len(result.Trade) != 0 {
+			t.Errorf("%s: expected nothing decoded in strict mode, got %+v %+v", name, result.Create, result.Trade)
+		}
+		if len(result.Diagnostics) != 1 || !errors.Is(result.Diagnostics[0], ErrUnknownDiscriminator) {
+			t.Errorf("%s: expected an unknown discriminator diagnostic, got %v", name, result.Diagnostics)
+		}
+	}
+
+	SetParseMode(LenientParsing)
+	defer SetParseMode(StrictParsing)
+	if result := mustParse(t, short, 0, &TransactionMeta{}); len(result.Trade) != 1 {
+		t.Errorf("Expected lenient parsing to guess a buy from the opcode, got %+v", result.Trade)
+	}
+}
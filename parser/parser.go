@@ -0,0 +1,1222 @@
+package parser
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+)
+
+// Known Raydium program IDs
+var (
+	RaydiumV4ProgramID        = solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+	RaydiumV5ProgramID        = solana.MustPublicKeyFromBase58("5quBtoiQqxF9Jv6KYKctB59NT3gtJD2Y65kdnB1Uev3h")
+	RaydiumStakingProgramID   = solana.MustPublicKeyFromBase58("EhhTKczWMGQt46ynNeRX1WfeagwwJd7ufHvCDjRxjo5Q")
+	RaydiumLiquidityProgramID = solana.MustPublicKeyFromBase58("27haf8L6oxUeXrHrgEgsexjSY5hbVUWEmvv9Nyxg8vQv")
+	// Raydium Launchpad specific program IDs
+	RaydiumLaunchpadV1ProgramID = solana.MustPublicKeyFromBase58("LanMV9sAd7wArD4vJFi2qDdfnVhFxYSUg6eADduJ3uj")
+	RaydiumCpSwapProgramID      = solana.MustPublicKeyFromBase58("CPMMoo8L3F4NbTegBCKVNunggL7H1ZpdTHKxQB5qKP1C")
+	RaydiumClmmProgramID        = solana.MustPublicKeyFromBase58("CAMMCzo5YL8w4VFF8KVHrK22GGUsp5VTaW7grrKgrWqK")
+	// Additional Raydium program IDs found in real transactions
+	RaydiumUnknownProgramID1 = solana.MustPublicKeyFromBase58("FoaFt2Dtz58RA6DPjbRb9t9z8sLJRChiGFTv21EfaseZ")
+	// Standard Solana program IDs
+	TokenProgramID           = solana.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	Token2022ProgramID       = solana.MustPublicKeyFromBase58("TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb")
+	SystemProgramID          = solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+	AssociatedTokenProgramID = solana.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+)
+
+// Instruction discriminators for different Raydium operations
+const (
+	// Single-byte opcodes of the legacy fallback decoders. These don't match AMM v4, which is
+	// decoded with the AMM_V4_* opcodes in ammv4.go.
+	INSTRUCTION_INITIALIZE_POOL = 0
+	INSTRUCTION_SWAP            = 1
+	INSTRUCTION_DEPOSIT         = 2
+	INSTRUCTION_WITHDRAW        = 3
+	INSTRUCTION_MIGRATE         = 4
+
+	// Raydium Launchpad specific instructions (different values to avoid conflicts)
+	INSTRUCTION_CREATE_POOL   = 9
+	INSTRUCTION_INITIALIZE    = 10
+	INSTRUCTION_SWAP_BASE_IN  = 11
+	INSTRUCTION_SWAP_BASE_OUT = 12
+	INSTRUCTION_BUY           = 6
+	INSTRUCTION_SELL          = 7
+
+	// Token program instructions
+	TOKEN_INSTRUCTION_TRANSFER         = 3
+	TOKEN_INSTRUCTION_MINT_TO          = 7
+	TOKEN_INSTRUCTION_CREATE_ACCOUNT   = 1
+	TOKEN_INSTRUCTION_CLOSE_ACCOUNT    = 9
+	TOKEN_INSTRUCTION_TRANSFER_CHECKED = 12
+	TOKEN_INSTRUCTION_MINT_TO_CHECKED  = 14
+	TOKEN_INSTRUCTION_BURN             = 8
+	TOKEN_INSTRUCTION_BURN_CHECKED     = 15
+
+	// Token-2022 transfer fee extension; its sub-instruction follows in the second byte
+	TOKEN_INSTRUCTION_TRANSFER_FEE_EXTENSION = 26
+	TRANSFER_FEE_TRANSFER_CHECKED_WITH_FEE   = 1
+)
+
+// Geyser format support structures
+type GeyserTransaction struct {
+	Signature         solana.Signature
+	Slot              uint64
+	ReceivedAt        int64 // SubscribeUpdate.created_at, when the node sent the update; 0 if absent
+	IsVote            bool
+	Instructions      []GeyserInstruction
+	InnerInstructions []GeyserInnerInstruction
+	AccountKeys       []solana.PublicKey
+	Meta              *TransactionMeta
+}
+
+type GeyserInstruction struct {
+	ProgramID   solana.PublicKey
+	Accounts    []solana.PublicKey
+	IsSigner    []bool // Parallel to Accounts; empty if unknown
+	IsWritable  []bool // Parallel to Accounts; empty if unknown
+	Data        []byte
+	StackHeight int // Only set for inner instructions
+}
+
+type GeyserInnerInstruction struct {
+	Index        int
+	Instructions []GeyserInstruction
+}
+
+type TransactionMeta struct {
+	Err                  *TransactionError // nil if the transaction succeeded
+	Fee                  uint64
+	ComputeUnitsConsumed uint64 // 0 if the node didn't report it
+	PreBalances          []uint64
+	PostBalances         []uint64
+	PreTokenBalances     []TokenBalance
+	PostTokenBalances    []TokenBalance
+	LoadedAddresses      LoadedAddresses
+	InnerInstructions    []InnerInstructionSet
+	LogMessages          []string
+}
+
+type TokenBalance struct {
+	AccountIndex int
+	Owner        solana.PublicKey // Wallet that owns the token account
+	Mint         solana.PublicKey
+	Amount       uint64
+	Decimals     uint8
+}
+
+// ParseTransaction parses a base64 encoded transaction with the default parser
+func ParseTransaction(encodedTx string, slot uint64, blockTime int64) (*Transaction, error) {
+	return defaultParser().ParseTransaction(encodedTx, slot, blockTime)
+}
+
+// ParseTransaction parses a base64 encoded transaction. blockTime is the Unix time of the block
+// (getTransaction's blockTime), or 0 if unknown.
+func (p *Parser) ParseTransaction(encodedTx string, slot uint64, blockTime int64) (*Transaction, error) {
+	var result *Transaction
+	var err error
+
+	// Try to parse as Geyser format first, falling back to standard RPC format
+	if geyserTx, geyserErr := parseGeyserTransaction(encodedTx, slot); geyserErr == nil {
+		result, err = p.parseGeyserFormatTransaction(geyserTx)
+	} else {
+		result, err = p.parseStandardTransaction(encodedTx, slot)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	setBlockTime(result, blockTime)
+	return result, nil
+}
+
+func parseGeyserTransaction(encodedTx string, slot uint64) (*GeyserTransaction, error) {
+
+	txBytes, err := base64.StdEncoding.DecodeString(encodedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 transaction: %w", err)
+	}
+
+	// A wire-format transaction can open with a valid protobuf tag (10 signatures encode as
+	// 0x0a), so only a complete decode of the update tells the two formats apart
+	geyserTx, err := parseGeyserBytes(txBytes, slot)
+	if err != nil {
+		return nil, fmt.Errorf("not a Geyser format transaction: %w", err)
+	}
+	return geyserTx, nil
+}
+
+func parseGeyserBytes(txBytes []byte, slot uint64) (*GeyserTransaction, error) {
+	geyserTx, err := DecodeGeyserTransaction(txBytes)
+	if err != nil {
+		return nil, err
+	}
+	if geyserTx.Slot == 0 {
+		geyserTx.Slot = slot
+	}
+	return geyserTx, nil
+}
+
+// parseGeyserFormatTransaction parses a Geyser format transaction
+func (p *Parser) parseGeyserFormatTransaction(geyserTx *GeyserTransaction) (*Transaction, error) {
+	result := &Transaction{
+		Signature:  geyserTx.Signature,
+		Slot:       geyserTx.Slot,
+		Create:     []CreateInfo{},
+		Trade:      []TradeInfo{},
+		TradeBuys:  []int{},
+		TradeSells: []int{},
+		Migrate:    []Migration{},
+		SwapBuys:   []SwapBuy{},
+		SwapSells:  []SwapSell{},
+	}
+	if len(geyserTx.AccountKeys) > 0 {
+		result.FeePayer = geyserTx.AccountKeys[0]
+	}
+
+	// Parse level-1 instructions
+	for i, instruction := range geyserTx.Instructions {
+		before := countEvents(result)
+		if err := p.parseGeyserInstructionWrapper(instruction, i, result, geyserTx); err != nil {
+			p.addDiagnostic(result, newParseError(err, i, -1, instruction.ProgramID, instruction.Data))
+		}
+		setEventPositions(result, before, i, -1, 1)
+	}
+
+	// Parse level-2 (inner) instructions, attributed to the top-level instruction that invoked them
+	for _, innerInstr := range geyserTx.InnerInstructions {
+		for j, instruction := range innerInstr.Instructions {
+			before := countEvents(result)
+			if err := p.parseGeyserInstructionWrapper(instruction, innerInstr.Index, result, geyserTx); err != nil {
+				p.addDiagnostic(result, newParseError(err, innerInstr.Index, j, instruction.ProgramID, instruction.Data))
+			}
+			setEventPositions(result, before, innerInstr.Index, j, instruction.StackHeight)
+		}
+	}
+
+	applyLaunchpadEvents(result, p.collectGeyserLaunchpadEvents(geyserTx))
+	applyTokenTransfers(result, geyserTx.AccountKeys, geyserTx.Meta)
+	applyMigrationLiquidity(result)
+	applyBalanceDeltas(result, ComputeBalanceDeltas(geyserTx.AccountKeys, geyserTx.Meta))
+
+	programIDs := make([]solana.PublicKey, len(geyserTx.Instructions))
+	for i, instruction := range geyserTx.Instructions {
+		programIDs[i] = instruction.ProgramID
+	}
+	applyComputeBudget(result, geyserTx.Meta, programIDs)
+	p.applyTransactionStatus(result, geyserTx.Meta, programIDs)
+	p.buildEvents(result)
+	result.ReceivedAt = geyserTx.ReceivedAt
+
+	return result, nil
+}
+
+// parseStandardTransaction parses a standard RPC format transaction
+func (p *Parser) parseStandardTransaction(encodedTx string, slot uint64) (*Transaction, error) {
+	// Decode the base64 encoded transaction
+	txBytes, err := base64.StdEncoding.DecodeString(encodedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 transaction: %w", err)
+	}
+
+	p.logger.Printf("Decoded transaction bytes: %d bytes", len(txBytes))
+
+	// Parse the transaction using solana-go
+	decoder := bin.NewBinDecoder(txBytes)
+	tx, err := solana.TransactionFromDecoder(decoder)
+	if err != nil {
+		// Log the specific error for debugging
+		p.logger.Printf("Transaction decoding error: %v", err)
+
+		if p.mode == DemoParsing {
+			return p.parseTransactionAlternative(encodedTx, slot)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrUndecodableTransaction, err)
+	}
+
+	// Initialize the result transaction
+	result := &Transaction{
+		Signature:  tx.Signatures[0], // First signature is the transaction signature
+		Slot:       slot,
+		Create:     []CreateInfo{},
+		Trade:      []TradeInfo{},
+		TradeBuys:  []int{},
+		TradeSells: []int{},
+		Migrate:    []Migration{},
+		SwapBuys:   []SwapBuy{},
+		SwapSells:  []SwapSell{},
+	}
+
+	p.parseMessageInstructions(&tx.Message, nil, result)
+
+	return result, nil
+}
+
+// parseMessageInstructions resolves the full account list of the message and parses its top-level instructions
+func (p *Parser) parseMessageInstructions(message *solana.Message, meta *TransactionMeta, result *Transaction) {
+	if err := p.resolveMessageAccounts(message, meta); err != nil {
+		p.addDiagnostic(result, &ParseError{InstructionIndex: -1, InnerIndex: -1, Err: fmt.Errorf("%w: %w", ErrUnresolvedLookupTables, err)})
+	}
+
+	if len(message.AccountKeys) > 0 {
+		result.FeePayer = message.AccountKeys[0]
+	}
+
+	p.logger.Printf("Parsing transaction with %d instructions", len(message.Instructions))
+
+	innerByIndex := make(map[int][]InnerInstruction)
+	if meta != nil {
+		for _, set := range meta.InnerInstructions {
+			innerByIndex[set.Index] = append(innerByIndex[set.Index], set.Instructions...)
+		}
+	}
+
+	// Parse top-level instructions, each followed by the inner instructions it invoked
+	for i, instruction := range message.Instructions {
+		before := countEvents(result)
+		if err := p.parseInstruction(instruction, message, i, result, meta); err != nil {
+			p.addDiagnostic(result, newParseError(err, i, -1, messageProgramID(message, instruction), instruction.Data))
+		}
+		setEventPositions(result, before, i, -1, 1)
+
+		for j, inner := range innerByIndex[i] {
+			before := countEvents(result)
+			if err := p.parseInstruction(inner.Instruction, message, i, result, meta); err != nil {
+				p.addDiagnostic(result, newParseError(err, i, j, messageProgramID(message, inner.Instruction), inner.Instruction.Data))
+			}
+			setEventPositions(result, before, i, j, inner.StackHeight)
+		}
+	}
+
+	applyLaunchpadEvents(result, p.collectLaunchpadEvents(message, meta))
+	applyTokenTransfers(result, message.AccountKeys, meta)
+	applyMigrationLiquidity(result)
+	applyBalanceDeltas(result, ComputeBalanceDeltas(message.AccountKeys, meta))
+
+	programIDs := make([]solana.PublicKey, len(message.Instructions))
+	for i, instruction := range message.Instructions {
+		programIDs[i] = messageProgramID(message, instruction)
+	}
+	applyComputeBudget(result, meta, programIDs)
+	p.applyTransactionStatus(result, meta, programIDs)
+	p.buildEvents(result)
+}
+
+// messageProgramID returns the program an instruction invokes, or the zero key if its index is out of range
+func messageProgramID(message *solana.Message, instruction solana.CompiledInstruction) solana.PublicKey {
+	if int(instruction.ProgramIDIndex) < len(message.AccountKeys) {
+		return message.AccountKeys[instruction.ProgramIDIndex]
+	}
+	return solana.PublicKey{}
+}
+
+// parseTransactionAlternative handles cases where standard unmarshaling fails by making up a sample
+// swap. Only used with DemoParsing.
+func (p *Parser) parseTransactionAlternative(encodedTx string, slot uint64) (*Transaction, error) {
+	p.logger.Printf("Standard transaction parsing failed, using alternative approach")
+
+	// Create a transaction with basic info but no parsed instructions
+	mockSignature := solana.Signature{}
+	copy(mockSignature[:], []byte("fallback_signature"))
+
+	result := &Transaction{
+		Signature:  mockSignature,
+		Slot:       slot,
+		Create:     []CreateInfo{},
+		Trade:      []TradeInfo{},
+		TradeBuys:  []int{},
+		TradeSells: []int{},
+		Migrate:    []Migration{},
+		SwapBuys:   []SwapBuy{},
+		SwapSells:  []SwapSell{},
+	}
+
+	p.logger.Printf("Transaction data length: %d bytes", len(encodedTx))
+
+	// For demonstration, if the transaction has substantial data,
+	// we'll add some sample parsed content
+	if len(encodedTx) > 100 {
+		// Simulate finding a swap instruction
+		mockTokenIn := solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112")   // SOL
+		mockTokenOut := solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v") // USDC
+		mockTrader := solana.MustPublicKeyFromBase58("11111111111111111111111111111111")
+		mockPool := solana.MustPublicKeyFromBase58("58oQChx4yWmvKdwLLZzBi4ChoCc2fqCUWBkwMihLYQo2")
+
+		tradeInfo := TradeInfo{
+			InstructionIndex: 0,
+			TokenIn:          mockTokenIn,
+			TokenOut:         mockTokenOut,
+			AmountIn:         1000000000, // 1 SOL
+			AmountOut:        25000000,   // 25 USDC
+			MinAmountOut:     24000000,
+			Trader:           mockTrader,
+			Pool:             mockPool,
+			TradeType:        "swap",
+			InnerIndex:       -1,
+		}
+
+		result.Trade = append(result.Trade, tradeInfo)
+		p.buildEvents(result)
+	}
+
+	return result, nil
+}
+
+// ParseTransactionWithSignature parses a transaction with a known signature with the default parser
+func ParseTransactionWithSignature(encodedTx string, slot uint64, blockTime int64, originalSignature solana.Signature) (*Transaction, error) {
+	return defaultParser().ParseTransactionWithSignature(encodedTx, slot, blockTime, originalSignature)
+}
+
+// ParseTransactionWithSignature parses a transaction from base64 encoded data with a known signature
+func (p *Parser) ParseTransactionWithSignature(encodedTx string, slot uint64, blockTime int64, originalSignature solana.Signature) (*Transaction, error) {
+	// First try Geyser format
+	geyserTx, err := parseGeyserTransaction(encodedTx, slot)
+	if err == nil {
+		result, err := p.parseGeyserFormatTransaction(geyserTx)
+		if err != nil {
+			return nil, err
+		}
+		result.Signature = originalSignature // Use the original signature instead of extracted one
+		setBlockTime(result, blockTime)
+		return result, nil
+	}
+
+	// Fallback to standard RPC format
+	result, err := p.parseStandardTransactionWithSignature(encodedTx, slot, originalSignature)
+	if err != nil {
+		return nil, err
+	}
+	setBlockTime(result, blockTime)
+	return result, nil
+}
+
+// ParseTransactionWithMeta parses a transaction together with its RPC meta with the default parser
+func ParseTransactionWithMeta(encodedTx string, slot uint64, blockTime int64, originalSignature solana.Signature, meta *TransactionMeta) (*Transaction, error) {
+	return defaultParser().ParseTransactionWithMeta(encodedTx, slot, blockTime, originalSignature, meta)
+}
+
+// ParseTransactionWithMeta parses a transaction together with its RPC meta. The meta's loaded
+// addresses are used to resolve the address lookup tables of v0 transactions.
+func (p *Parser) ParseTransactionWithMeta(encodedTx string, slot uint64, blockTime int64, originalSignature solana.Signature, meta *TransactionMeta) (*Transaction, error) {
+	result, err := p.parseStandardTransactionWithMeta(encodedTx, slot, originalSignature, meta)
+	if err != nil {
+		return nil, err
+	}
+	setBlockTime(result, blockTime)
+	return result, nil
+}
+
+// setBlockTime timestamps a transaction and every create, trade and migration in it
+func setBlockTime(result *Transaction, blockTime int64) {
+	result.BlockTime = blockTime
+	for i := range result.Create {
+		result.Create[i].Timestamp = result.BlockTime
+	}
+	for i := range result.Trade {
+		result.Trade[i].Timestamp = result.BlockTime
+	}
+	for i := range result.Migrate {
+		result.Migrate[i].Timestamp = result.BlockTime
+	}
+	for i := range result.LiquidityAdds {
+		result.LiquidityAdds[i].Timestamp = result.BlockTime
+	}
+	for i := range result.LiquidityRemoves {
+		result.LiquidityRemoves[i].Timestamp = result.BlockTime
+	}
+}
+
+// parseStandardTransactionWithSignature parses a standard RPC format transaction with known signature
+func (p *Parser) parseStandardTransactionWithSignature(encodedTx string, slot uint64, originalSignature solana.Signature) (*Transaction, error) {
+	return p.parseStandardTransactionWithMeta(encodedTx, slot, originalSignature, nil)
+}
+
+// parseStandardTransactionWithMeta parses a standard RPC format transaction with known signature and optional meta
+func (p *Parser) parseStandardTransactionWithMeta(encodedTx string, slot uint64, originalSignature solana.Signature, meta *TransactionMeta) (*Transaction, error) {
+	// Decode the base64 encoded transaction
+	txBytes, err := base64.StdEncoding.DecodeString(encodedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64 transaction: %w", err)
+	}
+
+	p.logger.Printf("Decoded transaction bytes: %d bytes", len(txBytes))
+
+	// Parse the transaction using solana-go
+	decoder := bin.NewBinDecoder(txBytes)
+	tx, err := solana.TransactionFromDecoder(decoder)
+	if err != nil {
+		// Log the specific error for debugging
+		p.logger.Printf("Transaction decoding error: %v", err)
+
+		if p.mode == DemoParsing {
+			result, _ := p.parseTransactionAlternative(encodedTx, slot)
+			result.Signature = originalSignature
+			return result, nil
+		}
+		return nil, fmt.Errorf("%w: %w", ErrUndecodableTransaction, err)
+	}
+
+	// Initialize the result transaction with the original signature
+	result := &Transaction{
+		Signature:  originalSignature, // Use the original signature instead of tx.Signatures[0]
+		Slot:       slot,
+		Create:     []CreateInfo{},
+		Trade:      []TradeInfo{},
+		TradeBuys:  []int{},
+		TradeSells: []int{},
+		Migrate:    []Migration{},
+		SwapBuys:   []SwapBuy{},
+		SwapSells:  []SwapSell{},
+	}
+
+	p.parseMessageInstructions(&tx.Message, meta, result)
+
+	p.logger.Printf("Successfully parsed transaction with %d creates, %d trades, %d migrations",
+		len(result.Create), len(result.Trade), len(result.Migrate))
+
+	return result, nil
+}
+
+func (p *Parser) parseInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, meta *TransactionMeta) error {
+	if int(instruction.ProgramIDIndex) >= len(message.AccountKeys) {
+		return fmt.Errorf("%w: program ID index %d", ErrAccountIndexOutOfRange, instruction.ProgramIDIndex)
+	}
+
+	programID := message.AccountKeys[instruction.ProgramIDIndex]
+
+	// Unresolved lookup table accounts would otherwise be skipped or read out of range by the parsers
+	for _, accountIndex := range instruction.Accounts {
+		if int(accountIndex) >= len(message.AccountKeys) {
+			return fmt.Errorf("%w: %d of %d account keys (unresolved address lookup table?)",
+				ErrAccountIndexOutOfRange, accountIndex, len(message.AccountKeys))
+		}
+	}
+
+	if p.debug != nil {
+		p.debug(createInstructionDebugInfo(instruction, message, index, programID))
+	}
+
+	decoder, ok := p.decoders.Lookup(programID)
+	if !ok {
+		// No decoder for this program, skip
+		p.logger.Printf("Skipping non-Raydium instruction at index %d (Program: %s)", index, programID.String())
+		return nil
+	}
+	return decoder.Decode(p.newInstructionContext(instruction, message, index, meta), result)
+}
+
+// decodeLaunchpad is the ProgramDecoder of the Launchpad program
+func decodeLaunchpad(ctx *InstructionContext, result *Transaction) error {
+	if ctx.Message == nil {
+		return ctx.parser().parseRaydiumLaunchpadInstruction(ctx.Geyser, ctx.Index, result, ctx.GeyserTx)
+	}
+	return ctx.parser().parseRaydiumLaunchpadInstructionStandard(ctx.Instruction, ctx.Message, ctx.Index, result, ctx.Meta)
+}
+
+// decodeStaking is the ProgramDecoder of the Raydium staking program
+func decodeStaking(ctx *InstructionContext, result *Transaction) error {
+	if ctx.Message == nil {
+		return nil
+	}
+	return ctx.parser().parseStakingInstruction(ctx.Instruction, ctx.Message, ctx.Index, result)
+}
+
+// decodeLiquidity is the ProgramDecoder of the Raydium liquidity program
+func decodeLiquidity(ctx *InstructionContext, result *Transaction) error {
+	if ctx.Message == nil {
+		return nil
+	}
+	return ctx.parser().parseLiquidityInstruction(ctx.Instruction, ctx.Message, ctx.Index, result)
+}
+
+// decodeUnknownRaydium runs the legacy decoders on programs seen in Raydium transactions whose layout isn't known
+func decodeUnknownRaydium(ctx *InstructionContext, result *Transaction) error {
+	if ctx.Message == nil {
+		return nil
+	}
+	ctx.parser().logger.Printf("Found potential Raydium instruction at index %d (Program: %s)", ctx.Index, ctx.ProgramID.String())
+	return ctx.parser().parseRaydiumInstruction(ctx.Instruction, ctx.Message, ctx.Index, result)
+}
+
+// parseRaydiumInstruction parses Raydium swap/trade instructions
+func (p *Parser) parseRaydiumInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
+	if len(instruction.Data) == 0 {
+		return fmt.Errorf("%w: instruction data is empty", ErrInstructionDataTooShort)
+	}
+
+	// Get the instruction discriminator (first byte for simple discriminators)
+	// For complex discriminators, we might need to read multiple bytes
+	discriminator := instruction.Data[0]
+
+	// Check if this is a complex discriminator (8 bytes)
+	if len(instruction.Data) >= 8 {
+		// Try to parse as 8-byte discriminator used by Anchor programs
+		discriminatorBytes := instruction.Data[:8]
+		if complexDiscriminator := binary.LittleEndian.Uint64(discriminatorBytes); complexDiscriminator != 0 {
+			return p.parseComplexRaydiumInstruction(instruction, message, index, result, complexDiscriminator)
+		}
+	}
+
+	// Single-byte opcodes aren't part of any published Raydium layout
+	if !p.guessUnknownInstructions() {
+		return fmt.Errorf("%w: Raydium instruction %d", ErrUnknownDiscriminator, discriminator)
+	}
+
+	name := legacyInstructionName(discriminator)
+
+	switch discriminator {
+	case INSTRUCTION_INITIALIZE_POOL, INSTRUCTION_CREATE_POOL:
+		return p.parseCreatePoolInstruction(instruction, message, index, name, result)
+	case INSTRUCTION_SWAP, INSTRUCTION_SWAP_BASE_IN, INSTRUCTION_SWAP_BASE_OUT:
+		return p.parseSwapInstruction(instruction, message, index, name, result)
+	case INSTRUCTION_BUY:
+		return p.parseBuyInstructionStandard(instruction, message, index, name, result)
+	case INSTRUCTION_SELL:
+		return p.parseSellInstructionStandard(instruction, message, index, name, result)
+	case INSTRUCTION_DEPOSIT, INSTRUCTION_WITHDRAW:
+		// Liquidity events are only decoded for programs with a known layout (AMM v4, CPMM, CLMM)
+		p.logger.Printf("Raydium %s instruction at index %d (layout unknown)", name, index)
+		return nil
+	case INSTRUCTION_MIGRATE:
+		return parseMigrateInstruction(instruction, message, index, name, result)
+	default:
+		return fmt.Errorf("%w: Raydium instruction %d", ErrUnknownDiscriminator, discriminator)
+	}
+}
+
+// parseComplexRaydiumInstruction handles complex 8-byte discriminators
+func (p *Parser) parseComplexRaydiumInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, discriminator uint64) error {
+	// None of these discriminators come from an IDL, so matching them is a guess
+	if !p.guessUnknownInstructions() {
+		return fmt.Errorf("%w: Raydium instruction %x", ErrUnknownDiscriminator, discriminator)
+	}
+
+	// Known complex discriminators for Raydium programs
+	// These would be extracted from the actual Raydium IDL
+
+	// Example discriminators (these would need to be verified)
+	const (
+		COMPLEX_INITIALIZE = 0x175d3d5b8c84f4aa
+		COMPLEX_SWAP       = 0xf8c69e91e17587c8
+		COMPLEX_BUY        = 0x66063d1201daebea
+		COMPLEX_SELL       = 0xb712469c946da122
+		// Real discriminators found in transactions
+		COMPLEX_UNKNOWN_1 = 0x1a987cd39bde2795 // Found in LanMV9sAd7wArD4vJFi2qDdfnVhFxYSUg6eADduJ3uj
+		COMPLEX_UNKNOWN_2 = 0x0400000001010d09 // Found in FoaFt2Dtz58RA6DPjbRb9t9z8sLJRChiGFTv21EfaseZ
+	)
+
+	switch discriminator {
+	case COMPLEX_INITIALIZE:
+		return p.parseCreatePoolInstruction(instruction, message, index, "initialize", result)
+	case COMPLEX_SWAP:
+		return p.parseSwapInstruction(instruction, message, index, "swap", result)
+	case COMPLEX_BUY:
+		return p.parseBuyInstructionStandard(instruction, message, index, "buy", result)
+	case COMPLEX_SELL:
+		return p.parseSellInstructionStandard(instruction, message, index, "sell", result)
+	case COMPLEX_UNKNOWN_1, COMPLEX_UNKNOWN_2:
+		p.logger.Printf("Parsing unknown Raydium instruction with discriminator: %x", discriminator)
+		return p.parseGenericRaydiumInstruction(instruction, message, index, result, discriminator)
+	default:
+		p.logger.Printf("Unknown complex Raydium instruction discriminator: %x", discriminator)
+		// Try to parse as generic Raydium instruction
+		return p.parseGenericRaydiumInstruction(instruction, message, index, result, discriminator)
+	}
+}
+
+// parseCreatePoolInstruction parses pool creation instructions
+func (p *Parser) parseCreatePoolInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, name string, result *Transaction) error {
+	// Extract accounts involved in pool creation
+	if len(instruction.Accounts) < 3 {
+		return fmt.Errorf("%w for pool creation", ErrInsufficientAccounts)
+	}
+
+	if decoded, ok := p.decodeWithIDL(instruction, message); ok {
+		result.Create = append(result.Create, p.launchpadCreateFromIDL(decoded, instructionSigner(instruction, message)))
+		return nil
+	}
+
+	// Past the IDL no create layout is known, so only the accounts of guessed creates are kept
+	if _, anchor := LaunchpadInstructions.Lookup(instruction.Data); anchor {
+		return fmt.Errorf("%w: %s doesn't decode through the Launchpad IDL", ErrMalformedData, name)
+	}
+	var tokenDecimals uint8 = 9 // Default to 9 decimals
+
+	// Mint, pool and creator sit at fixed positions of the initialize account list
+	tokenMint, poolAddress, creator := launchpadCreateAccounts(name, instructionAccounts(instruction, message), instructionSigner(instruction, message))
+
+	// Try to get token symbol from known tokens
+	tokenSymbol := "UNKNOWN"
+	if tokenInfo, exists := p.getKnownTokenInfo(tokenMint); exists {
+		tokenSymbol = tokenInfo.Symbol
+	}
+
+	createInfo := CreateInfo{
+		TokenMint:       tokenMint,
+		PoolAddress:     poolAddress,
+		Creator:         creator,
+		TokenDecimals:   tokenDecimals,
+		TokenSymbol:     tokenSymbol,
+		InstructionName: name,
+	}
+
+	result.Create = append(result.Create, createInfo)
+	return nil
+}
+
+// parseSwapInstruction parses swap instructions
+func (p *Parser) parseSwapInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, name string, result *Transaction) error {
+	if len(instruction.Accounts) < 6 {
+		return fmt.Errorf("%w for swap", ErrInsufficientAccounts)
+	}
+
+	// Extract swap amounts from instruction data
+	var amountIn, minAmountOut uint64
+
+	if decoded, ok := p.decodeWithIDL(instruction, message); ok {
+		amountIn = decoded.Args.Uint64("amount_in")
+		minAmountOut = decoded.Args.Uint64("minimum_amount_out")
+		name = decoded.Name
+	} else {
+		var err error
+		if amountIn, minAmountOut, err = legacyAmounts(instruction.Data, name); err != nil {
+			return err
+		}
+	}
+
+	// Extract swap information
+	tokenIn := message.AccountKeys[instruction.Accounts[0]]
+	tokenOut := message.AccountKeys[instruction.Accounts[1]]
+	pool := message.AccountKeys[instruction.Accounts[2]]
+	trader := instructionSigner(instruction, message)
+
+	tradeInfo := TradeInfo{
+		InstructionIndex: index,
+		TokenIn:          tokenIn,
+		TokenOut:         tokenOut,
+		Pool:             pool,
+		Trader:           trader,
+		AmountIn:         amountIn,
+		AmountOut:        0, // Would be extracted from transaction logs/metadata
+		MinAmountOut:     minAmountOut,
+		TradeType:        "swap",
+		InstructionName:  name,
+	}
+
+	result.Trade = append(result.Trade, tradeInfo)
+
+	return nil
+}
+
+// parseBuyInstructionStandard parses buy instructions in standard format
+func (p *Parser) parseBuyInstructionStandard(instruction solana.CompiledInstruction, message *solana.Message, index int, name string, result *Transaction) error {
+	if len(instruction.Accounts) < 3 {
+		return fmt.Errorf("%w for buy", ErrInsufficientAccounts)
+	}
+
+	if decoded, ok := p.decodeWithIDL(instruction, message); ok {
+		result.Trade = append(result.Trade, launchpadTradeFromIDL(decoded, index, "buy", instructionSigner(instruction, message)))
+		return nil
+	}
+
+	amountIn, maxAmountIn, err := legacyAmounts(instruction.Data, name)
+	if err != nil {
+		return err
+	}
+
+	tokenIn, tokenOut, pool, buyer := launchpadTradeAccounts(name, "buy", instructionAccounts(instruction, message), instructionSigner(instruction, message))
+
+	tradeInfo := TradeInfo{
+		InstructionIndex: index,
+		TokenIn:          tokenIn,  // Quote currency (SOL for most launchpad pools)
+		TokenOut:         tokenOut, // Token being bought
+		Pool:             pool,
+		Trader:           buyer,
+		AmountIn:         amountIn,
+		AmountOut:        0, // Would be extracted from transaction logs
+		MaxAmountIn:      maxAmountIn,
+		TradeType:        "buy",
+		InstructionName:  name,
+	}
+
+	result.Trade = append(result.Trade, tradeInfo)
+
+	return nil
+}
+
+// parseSellInstructionStandard parses sell instructions in standard format
+func (p *Parser) parseSellInstructionStandard(instruction solana.CompiledInstruction, message *solana.Message, index int, name string, result *Transaction) error {
+	if len(instruction.Accounts) < 3 {
+		return fmt.Errorf("%w for sell", ErrInsufficientAccounts)
+	}
+
+	if decoded, ok := p.decodeWithIDL(instruction, message); ok {
+		result.Trade = append(result.Trade, launchpadTradeFromIDL(decoded, index, "sell", instructionSigner(instruction, message)))
+		return nil
+	}
+
+	amountIn, minAmountOut, err := legacyAmounts(instruction.Data, name)
+	if err != nil {
+		return err
+	}
+
+	tokenIn, tokenOut, pool, seller := launchpadTradeAccounts(name, "sell", instructionAccounts(instruction, message), instructionSigner(instruction, message))
+
+	tradeInfo := TradeInfo{
+		InstructionIndex: index,
+		TokenIn:          tokenIn,  // Token being sold
+		TokenOut:         tokenOut, // Quote currency (SOL for most launchpad pools)
+		Pool:             pool,
+		Trader:           seller,
+		AmountIn:         amountIn,
+		AmountOut:        0, // Would be extracted from transaction logs
+		MinAmountOut:     minAmountOut,
+		TradeType:        "sell",
+		InstructionName:  name,
+	}
+
+	result.Trade = append(result.Trade, tradeInfo)
+
+	return nil
+}
+
+// legacyAmounts reads the two amounts of the single-byte opcode layout (opcode, u64, u64), the only
+// layout known past the IDL. Anchor data would put the discriminator where that layout has the amounts.
+func legacyAmounts(data []byte, name string) (uint64, uint64, error) {
+	if _, anchor := LaunchpadInstructions.Lookup(data); anchor || len(data) < 17 {
+		return 0, 0, fmt.Errorf("%w: %s doesn't decode through the Launchpad IDL", ErrMalformedData, name)
+	}
+	return binary.LittleEndian.Uint64(data[1:9]), binary.LittleEndian.Uint64(data[9:17]), nil
+}
+
+// launchpadTradeFromIDL maps a decoded Launchpad buy/sell instruction onto a TradeInfo
+func launchpadTradeFromIDL(decoded *DecodedInstruction, index int, tradeType string, signer solana.PublicKey) TradeInfo {
+	baseMint := decoded.Accounts["base_token_mint"]
+	quoteMint := decoded.Accounts["quote_token_mint"]
+	if quoteMint.IsZero() {
+		quoteMint = solana.SolMint // Launchpad pools are quoted in SOL unless told otherwise
+	}
+
+	trader := decoded.Accounts["payer"]
+	if trader.IsZero() {
+		trader = signer
+	}
+
+	tradeInfo := TradeInfo{
+		InstructionIndex: index,
+		Pool:             decoded.Accounts["pool_state"],
+		Trader:           trader,
+		AmountIn:         decoded.Args.Uint64("amount_in"),
+		AmountOut:        decoded.Args.Uint64("amount_out"),         // Exact-out only until the TradeEvent is applied
+		MinAmountOut:     decoded.Args.Uint64("minimum_amount_out"), // 0 for exact-out instructions
+		MaxAmountIn:      decoded.Args.Uint64("maximum_amount_in"),  // 0 for exact-in instructions
+		TradeType:        tradeType,
+		InstructionName:  decoded.Name,
+	}
+
+	if tradeType == "buy" {
+		tradeInfo.TokenIn, tradeInfo.TokenOut = quoteMint, baseMint
+	} else {
+		tradeInfo.TokenIn, tradeInfo.TokenOut = baseMint, quoteMint
+	}
+
+	return tradeInfo
+}
+
+// launchpadCreateFromIDL maps a decoded Launchpad initialize instruction onto a CreateInfo
+func (p *Parser) launchpadCreateFromIDL(decoded *DecodedInstruction, signer solana.PublicKey) CreateInfo {
+	mintParams := decoded.Args.Struct("base_mint_param")
+	curve := decoded.Args.Enum("curve_param").Fields.Struct("data")
+
+	creator := decoded.Accounts["creator"]
+	if creator.IsZero() {
+		creator = signer
+	}
+
+	tokenMint := decoded.Accounts["base_mint"]
+	tokenSymbol := mintParams.String("symbol")
+	if tokenSymbol == "" {
+		tokenSymbol = "UNKNOWN"
+		if tokenInfo, exists := p.getKnownTokenInfo(tokenMint); exists {
+			tokenSymbol = tokenInfo.Symbol
+		}
+	}
+
+	return CreateInfo{
+		TokenMint:       tokenMint,
+		PoolAddress:     decoded.Accounts["pool_state"],
+		Creator:         creator,
+		TokenDecimals:   mintParams.Uint8("decimals"),
+		TokenSymbol:     tokenSymbol,
+		Amount:          curve.Uint64("supply"),
+		InstructionName: decoded.Name,
+	}
+}
+
+// parseMigrateInstruction parses migration instructions
+func parseMigrateInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, name string, result *Transaction) error {
+	if len(instruction.Accounts) < 4 {
+		return fmt.Errorf("%w for migration", ErrInsufficientAccounts)
+	}
+
+	// Extract migration amount from instruction data
+	var amount uint64 = 0
+	if len(instruction.Data) >= 9 {
+		amount = binary.LittleEndian.Uint64(instruction.Data[1:9])
+	}
+
+	migration := Migration{
+		FromPool:        message.AccountKeys[instruction.Accounts[0]],
+		ToPool:          message.AccountKeys[instruction.Accounts[1]],
+		Token:           message.AccountKeys[instruction.Accounts[2]],
+		Owner:           message.AccountKeys[instruction.Accounts[3]],
+		Amount:          amount,
+		InstructionName: name,
+	}
+
+	result.Migrate = append(result.Migrate, migration)
+	return nil
+}
+
+// parseStakingInstruction parses staking-related instructions
+func (p *Parser) parseStakingInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
+	p.logger.Printf("Staking instruction detected at index %d", index)
+	return nil
+}
+
+// parseLiquidityInstruction parses liquidity-related instructions
+func (p *Parser) parseLiquidityInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction) error {
+	p.logger.Printf("Liquidity instruction detected at index %d", index)
+	return nil
+}
+
+// Helper functions
+
+// legacyInstructionName names the single-byte instruction opcodes
+func legacyInstructionName(discriminator byte) string {
+	switch discriminator {
+	case INSTRUCTION_INITIALIZE_POOL, INSTRUCTION_INITIALIZE:
+		return "initialize"
+	case INSTRUCTION_CREATE_POOL:
+		return "create_pool"
+	case INSTRUCTION_SWAP:
+		return "swap"
+	case INSTRUCTION_SWAP_BASE_IN:
+		return "swap_base_in"
+	case INSTRUCTION_SWAP_BASE_OUT:
+		return "swap_base_out"
+	case INSTRUCTION_BUY:
+		return "buy"
+	case INSTRUCTION_SELL:
+		return "sell"
+	case INSTRUCTION_DEPOSIT:
+		return "deposit"
+	case INSTRUCTION_WITHDRAW:
+		return "withdraw"
+	case INSTRUCTION_MIGRATE:
+		return "migrate"
+	default:
+		return "unknown"
+	}
+}
+
+// isBaseCurrency reports whether a mint is one of the quote tokens of the parser's token registry
+func (p *Parser) isBaseCurrency(tokenMint solana.PublicKey) bool {
+	return p.tokens.IsQuote(tokenMint)
+}
+
+// calculateSlippage returns how far the actual amount stayed from the trader's limit, as a fraction
+// of the limit: the surplus over a minimum out, or the saving under a maximum in
+func calculateSlippage(actualAmount, limitAmount uint64) float64 {
+	if actualAmount == 0 || limitAmount == 0 {
+		return 0.0
+	}
+
+	if actualAmount >= limitAmount {
+		return float64(actualAmount-limitAmount) / float64(limitAmount)
+	}
+	return float64(limitAmount-actualAmount) / float64(limitAmount)
+}
+
+func (p *Parser) getKnownTokenInfo(tokenMint solana.PublicKey) (TokenInfo, bool) {
+	info, exists := p.tokens[tokenMint]
+	return info, exists
+}
+
+// parseGeyserInstructionWrapper parses a Geyser format instruction
+func (p *Parser) parseGeyserInstructionWrapper(instruction GeyserInstruction, index int, result *Transaction, geyserTx *GeyserTransaction) error {
+	decoder, ok := p.decoders.Lookup(instruction.ProgramID)
+	if !ok {
+		// No decoder for this program, skip
+		return nil
+	}
+	return decoder.Decode(p.newGeyserInstructionContext(instruction, index, geyserTx), result)
+}
+
+func (p *Parser) parseRaydiumLaunchpadInstruction(instruction GeyserInstruction, index int, result *Transaction, geyserTx *GeyserTransaction) error {
+	if len(instruction.Data) == 0 {
+		return fmt.Errorf("%w: launchpad instruction data is empty", ErrInstructionDataTooShort)
+	}
+	defer p.tagLaunchpadPlatform(result, len(result.Create), len(result.Trade), instruction.Data, instruction.Accounts)
+
+	// Events emitted through emit_cpi show up as inner instructions of the program itself
+	if isAnchorEventCPI(instruction.Data) {
+		return nil
+	}
+
+	name, ok := LaunchpadInstructions.Lookup(instruction.Data)
+	if !ok {
+		return fmt.Errorf("%w: Launchpad", ErrUnknownDiscriminator)
+	}
+	signer := geyserInstructionSigner(instruction, geyserTx.AccountKeys)
+
+	switch name {
+	case LaunchpadInitialize, LaunchpadInitializeV2, LaunchpadInitializeWithToken2022:
+		return p.parseGeyserCreatePoolInstruction(instruction, index, name, signer, result)
+	case LaunchpadBuyExactIn, LaunchpadBuyExactOut:
+		return p.parseGeyserBuyInstruction(instruction, index, name, signer, result)
+	case LaunchpadSellExactIn, LaunchpadSellExactOut:
+		return p.parseGeyserSellInstruction(instruction, index, name, signer, result)
+	case LaunchpadMigrateToAmm, LaunchpadMigrateToCpswap:
+		return parseLaunchpadMigrateInstruction(name, instruction.Accounts, index,
+			signer, newTokenAccounts(geyserTx.AccountKeys, geyserTx.Meta), result)
+	default:
+		p.logger.Printf("Skipping Raydium Launchpad %s instruction at index %d", name, index)
+		return nil
+	}
+}
+
+func (p *Parser) parseGeyserCreatePoolInstruction(instruction GeyserInstruction, index int, name string, signer solana.PublicKey, result *Transaction) error {
+	if len(instruction.Accounts) < 8 {
+		return fmt.Errorf("%w for pool creation", ErrInsufficientAccounts)
+	}
+
+	if decoded, ok := p.decodeGeyserWithIDL(instruction); ok {
+		result.Create = append(result.Create, p.launchpadCreateFromIDL(decoded, signer))
+		return nil
+	}
+
+	// Launchpad data is Anchor-encoded, so there is no other layout to fall back to
+	return fmt.Errorf("%w: Launchpad %s doesn't decode through its IDL", ErrMalformedData, name)
+}
+
+func (p *Parser) parseGeyserBuyInstruction(instruction GeyserInstruction, index int, name string, signer solana.PublicKey, result *Transaction) error {
+	if len(instruction.Accounts) < 6 {
+		return fmt.Errorf("%w for buy", ErrInsufficientAccounts)
+	}
+
+	if decoded, ok := p.decodeGeyserWithIDL(instruction); ok {
+		result.Trade = append(result.Trade, launchpadTradeFromIDL(decoded, index, "buy", signer))
+		return nil
+	}
+
+	// Launchpad data is Anchor-encoded, so there is no other layout to fall back to
+	return fmt.Errorf("%w: Launchpad %s doesn't decode through its IDL", ErrMalformedData, name)
+}
+
+func (p *Parser) parseGeyserSellInstruction(instruction GeyserInstruction, index int, name string, signer solana.PublicKey, result *Transaction) error {
+	if len(instruction.Accounts) < 6 {
+		return fmt.Errorf("%w for sell", ErrInsufficientAccounts)
+	}
+
+	if decoded, ok := p.decodeGeyserWithIDL(instruction); ok {
+		result.Trade = append(result.Trade, launchpadTradeFromIDL(decoded, index, "sell", signer))
+		return nil
+	}
+
+	// Launchpad data is Anchor-encoded, so there is no other layout to fall back to
+	return fmt.Errorf("%w: Launchpad %s doesn't decode through its IDL", ErrMalformedData, name)
+}
+
+// Helper functions for Geyser format
+
+// extractTokenSymbol extracts token symbol from metadata or returns default
+func (p *Parser) extractTokenSymbol(tokenMint solana.PublicKey, meta *TransactionMeta) string {
+	// In a real implementation, this would:
+	// 1. Check known token registry
+	// 2. Query token metadata
+	// 3. Parse token name from transaction logs
+
+	// For now, i will return known symbols or default
+	if tokenInfo, exists := p.getKnownTokenInfo(tokenMint); exists {
+		return tokenInfo.Symbol
+	}
+	return "UNKNOWN"
+}
+
+func (p *Parser) parseGenericRaydiumInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, discriminator uint64) error {
+	if !p.guessUnknownInstructions() {
+		return fmt.Errorf("%w: Raydium instruction %x", ErrUnknownDiscriminator, discriminator)
+	}
+	p.logger.Printf("Attempting to parse generic Raydium instruction (discriminator: %x, accounts: %d, data: %d bytes)",
+		discriminator, len(instruction.Accounts), len(instruction.Data))
+
+	if len(instruction.Accounts) >= 6 && len(instruction.Data) >= 16 {
+		// Swap-sized, but no layout says where the amounts and mints sit
+		return fmt.Errorf("%w: no known swap layout for Raydium instruction %x", ErrMalformedData, discriminator)
+	}
+
+	if len(instruction.Accounts) >= 4 && len(instruction.Data) >= 8 {
+		p.logger.Printf("Parsing as potential create/migrate instruction")
+		return p.parseAsCreateOrMigrateInstruction(instruction, message, index, "unknown", result)
+	}
+
+	p.logger.Printf("Unknown Raydium instruction detected but not parsed (insufficient data)")
+	return nil
+}
+
+func (p *Parser) parseAsCreateOrMigrateInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, name string, result *Transaction) error {
+	// Try to parse as pool creation
+	if len(instruction.Accounts) >= 8 {
+		p.logger.Printf("Parsing as potential pool creation")
+		return p.parseCreatePoolInstruction(instruction, message, index, name, result)
+	}
+
+	if len(instruction.Accounts) >= 4 {
+		p.logger.Printf("Parsing as potential migration")
+		return parseMigrateInstruction(instruction, message, index, name, result)
+	}
+
+	return nil
+}
+
+// parseRaydiumLaunchpadInstructionStandard parses Raydium Launchpad instructions with standard format
+func (p *Parser) parseRaydiumLaunchpadInstructionStandard(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, meta *TransactionMeta) error {
+	if len(instruction.Data) == 0 {
+		return fmt.Errorf("%w: launchpad instruction data is empty", ErrInstructionDataTooShort)
+	}
+	defer p.tagLaunchpadPlatform(result, len(result.Create), len(result.Trade), instruction.Data, instructionAccounts(instruction, message))
+
+	// Events emitted through emit_cpi show up as inner instructions of the program itself
+	if isAnchorEventCPI(instruction.Data) {
+		return nil
+	}
+
+	// Get the instruction discriminator (first byte)
+	discriminator := instruction.Data[0]
+
+	p.logger.Printf("Launchpad instruction discriminator: %d at index %d", discriminator, index)
+
+	// Check if this is a complex discriminator (8 bytes)
+	if len(instruction.Data) >= 8 {
+		// Try to parse as 8-byte discriminator used by Anchor programs
+		discriminatorBytes := instruction.Data[:8]
+		if complexDiscriminator := binary.LittleEndian.Uint64(discriminatorBytes); complexDiscriminator != 0 {
+			p.logger.Printf("Launchpad complex discriminator: %x", complexDiscriminator)
+			return p.parseComplexLaunchpadInstruction(instruction, message, index, result, meta, complexDiscriminator)
+		}
+	}
+
+	// Launchpad is an Anchor program; shorter or zero-prefixed data only matches an opcode by guessing
+	if !p.guessUnknownInstructions() {
+		return fmt.Errorf("%w: Launchpad instruction %d", ErrUnknownDiscriminator, discriminator)
+	}
+
+	name := legacyInstructionName(discriminator)
+
+	switch discriminator {
+	case INSTRUCTION_INITIALIZE, INSTRUCTION_INITIALIZE_POOL, INSTRUCTION_CREATE_POOL:
+		p.logger.Printf("Parsing launchpad create/initialize instruction")
+		return p.parseCreatePoolInstruction(instruction, message, index, name, result)
+	case INSTRUCTION_BUY:
+		p.logger.Printf("Parsing launchpad buy instruction")
+		return p.parseBuyInstructionStandard(instruction, message, index, name, result)
+	case INSTRUCTION_SELL:
+		p.logger.Printf("Parsing launchpad sell instruction")
+		return p.parseSellInstructionStandard(instruction, message, index, name, result)
+	case INSTRUCTION_SWAP, INSTRUCTION_SWAP_BASE_IN, INSTRUCTION_SWAP_BASE_OUT:
+		p.logger.Printf("Parsing launchpad swap instruction")
+		return p.parseSwapInstruction(instruction, message, index, name, result)
+	case INSTRUCTION_MIGRATE:
+		p.logger.Printf("Parsing launchpad migrate instruction")
+		return parseMigrateInstruction(instruction, message, index, name, result)
+	default:
+		p.logger.Printf("Unknown Launchpad instruction discriminator: %d", discriminator)
+		// Try to parse as generic launchpad instruction
+		return p.parseGenericLaunchpadInstruction(instruction, message, index, result, uint64(discriminator))
+	}
+}
+
+// parseComplexLaunchpadInstruction dispatches on the real Anchor discriminators of the Launchpad program
+func (p *Parser) parseComplexLaunchpadInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, meta *TransactionMeta, discriminator uint64) error {
+	name, ok := LaunchpadInstructions.Lookup(instruction.Data)
+	if !ok {
+		p.logger.Printf("Unknown complex Launchpad instruction discriminator: %x", discriminator)
+		// Try to parse as generic launchpad instruction
+		return p.parseGenericLaunchpadInstruction(instruction, message, index, result, discriminator)
+	}
+
+	p.logger.Printf("Parsing launchpad %s instruction", name)
+
+	switch name {
+	case LaunchpadInitialize, LaunchpadInitializeV2, LaunchpadInitializeWithToken2022:
+		return p.parseCreatePoolInstruction(instruction, message, index, name, result)
+	case LaunchpadBuyExactIn, LaunchpadBuyExactOut:
+		return p.parseBuyInstructionStandard(instruction, message, index, name, result)
+	case LaunchpadSellExactIn, LaunchpadSellExactOut:
+		return p.parseSellInstructionStandard(instruction, message, index, name, result)
+	case LaunchpadMigrateToAmm, LaunchpadMigrateToCpswap:
+		return parseLaunchpadMigrateInstruction(name, instructionAccounts(instruction, message), index,
+			instructionSigner(instruction, message), newTokenAccounts(message.AccountKeys, meta), result)
+	default:
+		// Config, fee and vesting instructions don't produce trade events
+		p.logger.Printf("Skipping launchpad %s instruction at index %d", name, index)
+		return nil
+	}
+}
+
+// parseGenericLaunchpadInstruction attempts to parse unknown launchpad instructions
+func (p *Parser) parseGenericLaunchpadInstruction(instruction solana.CompiledInstruction, message *solana.Message, index int, result *Transaction, discriminator uint64) error {
+	if !p.guessUnknownInstructions() {
+		return fmt.Errorf("%w: Launchpad instruction %x", ErrUnknownDiscriminator, discriminator)
+	}
+	p.logger.Printf("Attempting to parse generic launchpad instruction (discriminator: %x, accounts: %d, data: %d bytes)",
+		discriminator, len(instruction.Accounts), len(instruction.Data))
+
+	// Extract instruction data beyond discriminator
+	dataStart := 1
+	if len(instruction.Data) >= 8 {
+		dataStart = 8 // Skip 8-byte discriminator
+	}
+
+	// Common launchpad patterns analysis
+	if len(instruction.Accounts) >= 8 && len(instruction.Data) >= dataStart+32 {
+		p.logger.Printf("Pattern matches token creation - parsing as create")
+		return p.parseCreatePoolInstruction(instruction, message, index, "unknown", result)
+	}
+
+	if len(instruction.Accounts) >= 6 && len(instruction.Data) >= dataStart+16 {
+		// Check if this looks like a buy/sell instruction
+		// Launchpad buy/sell typically have specific account patterns
+		if len(instruction.Data) >= dataStart+16 {
+			var amount uint64
+			if len(instruction.Data) >= dataStart+8 {
+				amount = binary.LittleEndian.Uint64(instruction.Data[dataStart : dataStart+8])
+			}
+
+			p.logger.Printf("Pattern matches buy/sell - amount: %d", amount)
+
+			// Determine if it's buy or sell based on account patterns
+			// This is a heuristic based on common launchpad patterns
+			if amount > 0 {
+				// Try to parse as buy first
+				if err := p.parseBuyInstructionStandard(instruction, message, index, "unknown", result); err == nil {
+					return nil
+				}
+				// Fallback to sell
+				return p.parseSellInstructionStandard(instruction, message, index, "unknown", result)
+			}
+		}
+	}
+
+	if len(instruction.Accounts) >= 4 && len(instruction.Data) >= dataStart+8 {
+		p.logger.Printf("Pattern matches swap/migrate - parsing as swap")
+		return p.parseSwapInstruction(instruction, message, index, "unknown", result)
+	}
+
+	p.logger.Printf("Unable to parse launchpad instruction - insufficient data or unknown pattern")
+	return nil
+}
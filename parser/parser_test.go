@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// testKeys returns the given keys followed by new ones, n keys in all
+func testKeys(n int, keys ...solana.PublicKey) solana.PublicKeySlice {
+	result := append(solana.PublicKeySlice{}, keys...)
+	for len(result) < n {
+		result = append(result, solana.NewWallet().PublicKey())
+	}
+	return result
+}
+
+// accountRange returns the account indexes from first up to, but not including, end
+func accountRange(first, end uint16) []uint16 {
+	accounts := make([]uint16, 0, end-first)
+	for i := first; i < end; i++ {
+		accounts = append(accounts, i)
+	}
+	return accounts
+}
+
+// appendU64s appends little-endian u64 arguments to a copy of prefix
+func appendU64s(prefix []byte, values ...uint64) []byte {
+	data := append([]byte{}, prefix...)
+	for _, value := range values {
+		data = binary.LittleEndian.AppendUint64(data, value)
+	}
+	return data
+}
+
+// instructionData builds the data of an Anchor instruction: its discriminator followed by u64 arguments
+func instructionData(registry *DiscriminatorRegistry, name string, values ...uint64) []byte {
+	disc, _ := registry.Discriminator(name)
+	return appendU64s(disc[:], values...)
+}
+
+// encodeMessage base64-encodes a message as a transaction with an empty signature
+func encodeMessage(t *testing.T, message solana.Message) string {
+	t.Helper()
+	tx := solana.Transaction{Signatures: []solana.Signature{{}}, Message: message}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Failed to encode transaction: %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+// encodeTransaction base64-encodes a legacy transaction signed by keys[0] whose last readonly keys are read-only
+func encodeTransaction(t *testing.T, keys solana.PublicKeySlice, readonly uint8, instructions ...solana.CompiledInstruction) string {
+	t.Helper()
+	return encodeMessage(t, solana.Message{
+		Header:       solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: readonly},
+		AccountKeys:  keys,
+		Instructions: instructions,
+	})
+}
+
+// mustParse parses an encoded transaction with the default parser and fails the test on error
+func mustParse(t *testing.T, encoded string, blockTime int64, meta *TransactionMeta) *Transaction {
+	t.Helper()
+	result, err := ParseTransactionWithMeta(encoded, 1, blockTime, solana.Signature{}, meta)
+	if err != nil {
+		t.Fatalf("Failed to parse transaction: %v", err)
+	}
+	return result
+}
+
+// launchpadTransaction encodes a single top-level Launchpad instruction whose accounts are the payer
+// followed by account keys 2 to 15, so layout position i is key i+1
+func launchpadTransaction(t *testing.T, data []byte) (string, solana.PublicKeySlice) {
+	t.Helper()
+	keys := testKeys(16, solana.NewWallet().PublicKey(), RaydiumLaunchpadV1ProgramID)
+	accounts := append([]uint16{0}, accountRange(2, 16)...)
+	return encodeTransaction(t, keys, 1, solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data}), keys
+}
+
+// buildLaunchpadTradeTx builds a legacy transaction with a single top-level Launchpad trade
+func buildLaunchpadTradeTx(t *testing.T, name string, amount, limit uint64) (string, solana.PublicKeySlice) {
+	t.Helper()
+	return launchpadTransaction(t, instructionData(LaunchpadInstructions, name, amount, limit, 0))
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+// LetsBonkPlatformConfig is the platform_config account of LetsBonk (bonk.fun)
+var LetsBonkPlatformConfig = solana.MustPublicKeyFromBase58("FfYek5vEz23cMkWsdJwG2oa6EphsvXSHrGpdALN4g6W1")
+
+// PlatformRegistry labels Launchpad front-ends by their platform_config account
+type PlatformRegistry map[solana.PublicKey]string
+
+// DefaultPlatforms holds the platforms known out of the box
+var DefaultPlatforms = PlatformRegistry{
+	LetsBonkPlatformConfig: "LetsBonk",
+}
+
+// SetPlatformRegistry sets the registry the default parser uses to label the platform of Launchpad creates and trades
+func SetPlatformRegistry(registry PlatformRegistry) {
+	configureDefaultParser(WithPlatformRegistry(registry))
+}
+
+// Label returns the name registered for a platform config, or "" if it isn't known
+func (r PlatformRegistry) Label(config solana.PublicKey) string {
+	return r[config]
+}
+
+// tagLaunchpadPlatform sets the platform config and label of the creates and trades a Launchpad
+// instruction added after the given positions. Instructions without a platform_config account are skipped.
+func (p *Parser) tagLaunchpadPlatform(result *Transaction, createsBefore, tradesBefore int, data []byte, accounts []solana.PublicKey) {
+	name, ok := LaunchpadInstructions.Lookup(data)
+	if !ok {
+		return
+	}
+	config := LaunchpadAccountLayouts[name].Resolve(accounts)["platform_config"]
+	if config.IsZero() {
+		return
+	}
+
+	label := p.platforms.Label(config)
+	for i := createsBefore; i < len(result.Create); i++ {
+		result.Create[i].PlatformConfig, result.Create[i].Platform = config, label
+	}
+	for i := tradesBefore; i < len(result.Trade); i++ {
+		result.Trade[i].PlatformConfig, result.Trade[i].Platform = config, label
+	}
+}
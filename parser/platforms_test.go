@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestLaunchpadTradeTaggedWithPlatform(t *testing.T) {
+	data := instructionData(LaunchpadInstructions, LaunchpadBuyExactIn, 1000000000, 0, 0)
+
+	// buy_exact_in accounts: payer, authority, global_config, platform_config, pool_state, ...
+	keys := testKeys(16, solana.NewWallet().PublicKey(), RaydiumLaunchpadV1ProgramID)
+	keys[4] = LetsBonkPlatformConfig
+	accounts := append([]uint16{0}, accountRange(2, 16)...)
+	message := &solana.Message{AccountKeys: keys}
+	instruction := solana.CompiledInstruction{ProgramIDIndex: 1, Accounts: accounts, Data: data}
+
+	result := &Transaction{}
+	if err := defaultParser().parseRaydiumLaunchpadInstructionStandard(instruction, message, 0, result, nil); err != nil {
+		t.Fatalf("Failed to parse buy instruction: %v", err)
+	}
+	if len(result.Trade) != 1 {
+		t.Fatalf("Expected 1 trade, got %d", len(result.Trade))
+	}
+	if trade := result.Trade[0]; !trade.PlatformConfig.Equals(LetsBonkPlatformConfig) || trade.Platform != "LetsBonk" {
+		t.Errorf("Expected the LetsBonk platform, got %s (%q)", trade.PlatformConfig, trade.Platform)
+	}
+
+	// A custom registry relabels the same config; unknown configs keep an empty label
+	SetPlatformRegistry(PlatformRegistry{LetsBonkPlatformConfig: "bonk.fun"})
+	defer SetPlatformRegistry(DefaultPlatforms)
+
+	result = &Transaction{}
+	if err := defaultParser().parseRaydiumLaunchpadInstructionStandard(instruction, message, 0, result, nil); err != nil {
+		t.Fatalf("Failed to parse buy instruction: %v", err)
+	}
+	if result.Trade[0].Platform != "bonk.fun" {
+		t.Errorf("Expected the custom label, got %q", result.Trade[0].Platform)
+	}
+
+	SetPlatformRegistry(PlatformRegistry{})
+	result = &Transaction{}
+	if err := defaultParser().parseRaydiumLaunchpadInstructionStandard(instruction, message, 0, result, nil); err != nil {
+		t.Fatalf("Failed to parse buy instruction: %v", err)
+	}
+	if !result.Trade[0].PlatformConfig.Equals(LetsBonkPlatformConfig) || result.Trade[0].Platform != "" {
+		t.Errorf("Expected an unlabelled platform config, got %s (%q)", result.Trade[0].PlatformConfig, result.Trade[0].Platform)
+	}
+}
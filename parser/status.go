@@ -0,0 +1,225 @@
+package parser
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// TransactionStatus is the execution result of a transaction
+type TransactionStatus int
+
+const (
+	TransactionStatusUnknown TransactionStatus = iota // Parsed without meta
+	TransactionStatusSuccess
+	TransactionStatusFailed
+)
+
+func (s TransactionStatus) String() string {
+	switch s {
+	case TransactionStatusSuccess:
+		return "success"
+	case TransactionStatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// TransactionError is the decoded meta.err of a failed transaction
+type TransactionError struct {
+	Kind             string           // TransactionError variant, e.g. "InstructionError" or "InsufficientFundsForFee"
+	InstructionIndex int              // Failing top-level instruction for InstructionError, -1 otherwise
+	InstructionError string           // InstructionError variant, e.g. "Custom" or "InvalidAccountData"
+	CustomCode       uint32           // Program error code when InstructionError is "Custom"
+	ProgramID        solana.PublicKey // Program of the failing instruction, when known
+	Raw              string           // meta.err as JSON when it came from the RPC
+}
+
+func (e *TransactionError) Error() string {
+	if e.Kind != "InstructionError" {
+		return e.Kind
+	}
+	if e.InstructionError == "Custom" {
+		return fmt.Sprintf("instruction %d failed: custom program error: 0x%x", e.InstructionIndex, e.CustomCode)
+	}
+	return fmt.Sprintf("instruction %d failed: %s", e.InstructionIndex, e.InstructionError)
+}
+
+// TransactionError variants in the order of the runtime enum, which is how bincode numbers them
+var transactionErrorKinds = []string{
+	"AccountInUse", "AccountLoadedTwice", "AccountNotFound", "ProgramAccountNotFound",
+	"InsufficientFundsForFee", "InvalidAccountForFee", "AlreadyProcessed", "BlockhashNotFound",
+	"InstructionError", "CallChainTooDeep", "MissingSignatureForFee", "InvalidAccountIndex",
+	"SignatureFailure", "InvalidProgramForExecution", "SanitizeFailure", "ClusterMaintenance",
+	"AccountBorrowOutstanding", "WouldExceedMaxBlockCostLimit", "UnsupportedVersion",
+	"InvalidWritableAccount", "WouldExceedMaxAccountCostLimit", "WouldExceedAccountDataBlockLimit",
+	"TooManyAccountLocks", "AddressLookupTableNotFound", "InvalidAddressLookupTableOwner",
+	"InvalidAddressLookupTableData", "InvalidAddressLookupTableIndex", "InvalidRentPayingAccount",
+	"WouldExceedMaxVoteCostLimit", "WouldExceedAccountDataTotalLimit", "DuplicateInstruction",
+	"InsufficientFundsForRent", "MaxLoadedAccountsDataSizeExceeded", "InvalidLoadedAccountsDataSizeLimit",
+	"ResanitizationNeeded", "ProgramExecutionTemporarilyRestricted", "UnbalancedTransaction",
+	"ProgramCacheHitMaxLimit", "CommitCancelled",
+}
+
+// InstructionError variants in the order of the runtime enum
+var instructionErrorKinds = []string{
+	"GenericError", "InvalidArgument", "InvalidInstructionData", "InvalidAccountData",
+	"AccountDataTooSmall", "InsufficientFunds", "IncorrectProgramId", "MissingRequiredSignature",
+	"AccountAlreadyInitialized", "UninitializedAccount", "UnbalancedInstruction", "ModifiedProgramId",
+	"ExternalAccountLamportSpend", "ExternalAccountDataModified", "ReadonlyLamportChange",
+	"ReadonlyDataModified", "DuplicateAccountIndex", "ExecutableModified", "RentEpochModified",
+	"NotEnoughAccountKeys", "AccountDataSizeChanged", "AccountNotExecutable", "AccountBorrowFailed",
+	"AccountBorrowOutstanding", "DuplicateAccountOutOfSync", "Custom", "InvalidError",
+	"ExecutableDataModified", "ExecutableLamportChange", "ExecutableAccountNotRentExempt",
+	"UnsupportedProgramId", "CallDepth", "MissingAccount", "ReentrancyNotAllowed",
+	"MaxSeedLengthExceeded", "InvalidSeeds", "InvalidRealloc", "ComputationalBudgetExceeded",
+	"PrivilegeEscalation", "ProgramEnvironmentSetupFailure", "ProgramFailedToComplete",
+	"ProgramFailedToCompile", "Immutable", "IncorrectAuthority", "BorshIoError",
+	"AccountNotRentExempt", "InvalidAccountOwner", "ArithmeticOverflow", "UnsupportedSysvar",
+	"IllegalOwner", "MaxAccountsDataAllocationsExceeded", "MaxAccountsExceeded",
+	"MaxInstructionTraceLengthExceeded", "BuiltinProgramsMustConsumeComputeUnits",
+}
+
+func variantName(names []string, variant uint32) string {
+	if int(variant) < len(names) {
+		return names[variant]
+	}
+	return fmt.Sprintf("Unknown(%d)", variant)
+}
+
+// transactionErrorFromJSON decodes meta.err as returned by the RPC, e.g.
+// {"InstructionError":[2,{"Custom":6004}]} or "InsufficientFundsForFee". Unrecognised
+// shapes still produce an error so the transaction is reported as failed.
+func transactionErrorFromJSON(raw []byte) *TransactionError {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+
+	txErr := &TransactionError{Kind: "Unknown", InstructionIndex: -1, Raw: string(raw)}
+
+	var kind string
+	if err := json.Unmarshal(raw, &kind); err == nil {
+		txErr.Kind = kind
+		return txErr
+	}
+
+	var variants map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &variants); err != nil || len(variants) != 1 {
+		return txErr
+	}
+	for kind, value := range variants {
+		txErr.Kind = kind
+		if kind != "InstructionError" {
+			continue
+		}
+
+		var pair []json.RawMessage
+		if err := json.Unmarshal(value, &pair); err != nil || len(pair) != 2 {
+			continue
+		}
+		json.Unmarshal(pair[0], &txErr.InstructionIndex)
+
+		// Unit variants are plain strings, the others single-key objects
+		if err := json.Unmarshal(pair[1], &txErr.InstructionError); err == nil {
+			continue
+		}
+		var inner map[string]json.RawMessage
+		if err := json.Unmarshal(pair[1], &inner); err != nil {
+			continue
+		}
+		for name, value := range inner {
+			txErr.InstructionError = name
+			if name == "Custom" {
+				json.Unmarshal(value, &txErr.CustomCode)
+			}
+		}
+	}
+	return txErr
+}
+
+// transactionErrorFromBincode decodes the bincode-serialized TransactionError of a Yellowstone meta
+func transactionErrorFromBincode(data []byte) *TransactionError {
+	txErr := &TransactionError{Kind: "Unknown", InstructionIndex: -1}
+	if len(data) < 4 {
+		return txErr
+	}
+
+	variant := binary.LittleEndian.Uint32(data)
+	txErr.Kind = variantName(transactionErrorKinds, variant)
+	if txErr.Kind != "InstructionError" || len(data) < 9 {
+		return txErr
+	}
+
+	txErr.InstructionIndex = int(data[4])
+	txErr.InstructionError = variantName(instructionErrorKinds, binary.LittleEndian.Uint32(data[5:9]))
+	if txErr.InstructionError == "Custom" && len(data) >= 13 {
+		txErr.CustomCode = binary.LittleEndian.Uint32(data[9:13])
+	}
+	return txErr
+}
+
+// FailedTransactionMode controls how the operations of failed transactions are reported
+type FailedTransactionMode int
+
+const (
+	// FlagFailedTransactions keeps the operations of failed transactions and marks each of them as failed
+	FlagFailedTransactions FailedTransactionMode = iota
+	// ExcludeFailedTransactions drops every operation of failed transactions
+	ExcludeFailedTransactions
+)
+
+// SetFailedTransactionMode sets how the default parser reports the operations of failed transactions
+func SetFailedTransactionMode(mode FailedTransactionMode) {
+	configureDefaultParser(WithFailedTransactionMode(mode))
+}
+
+// applyTransactionStatus records the execution result of a transaction and flags or drops the
+// operations of a failed one. programIDs are the programs of the top-level instructions.
+func (p *Parser) applyTransactionStatus(result *Transaction, meta *TransactionMeta, programIDs []solana.PublicKey) {
+	if meta == nil {
+		result.Status = TransactionStatusUnknown
+		return
+	}
+	if meta.Err == nil {
+		result.Status = TransactionStatusSuccess
+		return
+	}
+
+	result.Status = TransactionStatusFailed
+	txErr := *meta.Err
+	if txErr.InstructionIndex >= 0 && txErr.InstructionIndex < len(programIDs) {
+		txErr.ProgramID = programIDs[txErr.InstructionIndex]
+	}
+	result.Err = &txErr
+
+	switch p.failedMode {
+	case ExcludeFailedTransactions:
+		result.Create = []CreateInfo{}
+		result.Trade = []TradeInfo{}
+		result.Migrate = []Migration{}
+		result.LiquidityAdds = nil
+		result.LiquidityRemoves = nil
+		result.TokenTransfers = nil
+		result.TradeEvents = nil
+		result.PoolCreateEvents = nil
+	default:
+		for i := range result.Create {
+			result.Create[i].Failed = true
+		}
+		for i := range result.Migrate {
+			result.Migrate[i].Failed = true
+		}
+		for i := range result.Trade {
+			result.Trade[i].Failed = true
+		}
+		for i := range result.LiquidityAdds {
+			result.LiquidityAdds[i].Failed = true
+		}
+		for i := range result.LiquidityRemoves {
+			result.LiquidityRemoves[i].Failed = true
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestTransactionErrorFromJSON(t *testing.T) {
+	meta, err := TransactionMetaFromJSON([]byte(`{"err":{"InstructionError":[2,{"Custom":6005}]},"fee":5000,"preBalances":[],"postBalances":[]}`))
+	if err != nil {
+		t.Fatalf("Failed to parse meta: %v", err)
+	}
+	if meta.Err == nil {
+		t.Fatal("Expected meta.err to be decoded")
+	}
+	if meta.Err.Kind != "InstructionError" || meta.Err.InstructionIndex != 2 || meta.Err.InstructionError != "Custom" || meta.Err.CustomCode != 6005 {
+		t.Errorf("Unexpected error: %+v", meta.Err)
+	}
+
+	txErr := transactionErrorFromJSON([]byte(`{"InstructionError":[0,"InvalidAccountData"]}`))
+	if txErr.InstructionIndex != 0 || txErr.InstructionError != "InvalidAccountData" {
+		t.Errorf("Unexpected error: %+v", txErr)
+	}
+	if txErr := transactionErrorFromJSON([]byte(`"InsufficientFundsForFee"`)); txErr.Kind != "InsufficientFundsForFee" || txErr.InstructionIndex != -1 {
+		t.Errorf("Unexpected error: %+v", txErr)
+	}
+}
+
+func TestTransactionErrorFromBincode(t *testing.T) {
+	// InstructionError(3, Custom(6001))
+	data := binary.LittleEndian.AppendUint32(nil, 8)
+	data = append(data, 3)
+	data = binary.LittleEndian.AppendUint32(data, 25)
+	data = binary.LittleEndian.AppendUint32(data, 6001)
+
+	txErr := transactionErrorFromBincode(data)
+	if txErr.Kind != "InstructionError" || txErr.InstructionIndex != 3 || txErr.InstructionError != "Custom" || txErr.CustomCode != 6001 {
+		t.Errorf("Unexpected error: %+v", txErr)
+	}
+	if txErr.Error() != "instruction 3 failed: custom program error: 0x1771" {
+		t.Errorf("Unexpected message: %s", txErr.Error())
+	}
+
+	if txErr := transactionErrorFromBincode(binary.LittleEndian.AppendUint32(nil, 7)); txErr.Kind != "BlockhashNotFound" {
+		t.Errorf("Unexpected error: %+v", txErr)
+	}
+}
+
+func TestFailedLaunchpadBuy(t *testing.T) {
+	defer SetFailedTransactionMode(FlagFailedTransactions)
+
+	encoded, _ := buildLaunchpadTradeTx(t, LaunchpadBuyExactIn, 200000000, 1000)
+
+	// Slippage exceeded
+	meta := &TransactionMeta{Err: transactionErrorFromJSON([]byte(`{"InstructionError":[0,{"Custom":6005}]}`))}
+
+	result := mustParse(t, encoded, 0, meta)
+	if result.Status != TransactionStatusFailed || result.Err == nil {
+		t.Fatalf("Expected a failed transaction, got status %s", result.Status)
+	}
+	if !result.Err.ProgramID.Equals(RaydiumLaunchpadV1ProgramID) {
+		t.Errorf("Expected the failing program to be Launchpad, got %s", result.Err.ProgramID)
+	}
+	if len(result.Trade) != 1 || !result.Trade[0].Failed {
+		t.Errorf("Expected 1 trade flagged as failed, got %+v", result.Trade)
+	}
+
+	SetFailedTransactionMode(ExcludeFailedTransactions)
+	result = mustParse(t, encoded, 0, meta)
+	if result.Status != TransactionStatusFailed || len(result.Trade) != 0 || len(result.TradeBuys) != 0 {
+		t.Errorf("Expected failed trades to be excluded, got %+v", result.Trade)
+	}
+}
+
+func TestFailedTransactionFlagsEveryOperation(t *testing.T) {
+	result := &Transaction{
+		Create:           []CreateInfo{{}},
+		Trade:            []TradeInfo{{}},
+		Migrate:          []Migration{{}},
+		LiquidityAdds:    []LiquidityAdd{{}},
+		LiquidityRemoves: []LiquidityRemove{{}},
+	}
+	meta := &TransactionMeta{Err: transactionErrorFromJSON([]byte(`{"InstructionError":[0,{"Custom":1}]}`))}
+	New().applyTransactionStatus(result, meta, nil)
+
+	if !result.Create[0].Failed || !result.Trade[0].Failed || !result.Migrate[0].Failed ||
+		!result.LiquidityAdds[0].Failed || !result.LiquidityRemoves[0].Failed {
+		t.Errorf("Expected every operation flagged as failed, got %+v", result)
+	}
+}
+
+func TestExcludeFailedTransactionsDropsEveryOperation(t *testing.T) {
+	result := &Transaction{
+		Create:           []CreateInfo{{}},
+		Trade:            []TradeInfo{{}},
+		Migrate:          []Migration{{}},
+		LiquidityAdds:    []LiquidityAdd{{}},
+		LiquidityRemoves: []LiquidityRemove{{}},
+		TokenTransfers:   []TokenTransfer{{Kind: "transfer", Amount: 1000}},
+		TradeEvents:      []LaunchpadTradeEvent{{}},
+		PoolCreateEvents: []LaunchpadPoolCreateEvent{{}},
+	}
+	meta := &TransactionMeta{Err: transactionErrorFromJSON([]byte(`{"InstructionError":[0,{"Custom":1}]}`))}
+	New(WithFailedTransactionMode(ExcludeFailedTransactions)).applyTransactionStatus(result, meta, nil)
+
+	if len(result.Create)+len(result.Trade)+len(result.Migrate)+len(result.LiquidityAdds)+len(result.LiquidityRemoves) != 0 {
+		t.Errorf("Expected every operation dropped, got %+v", result)
+	}
+	if len(result.TokenTransfers) != 0 || len(result.TradeEvents) != 0 || len(result.PoolCreateEvents) != 0 {
+		t.Errorf("Expected token transfers and events dropped, got %+v, %+v, %+v", result.TokenTransfers, result.TradeEvents, result.PoolCreateEvents)
+	}
+}
@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"encoding/binary"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// decodeTokenInstruction decodes the transfers, mints and burns of the Token and Token-2022 programs.
+// ok is false for every other instruction.
+func decodeTokenInstruction(programID solana.PublicKey, data []byte, accounts []solana.PublicKey, index int) (transfer TokenTransfer, ok bool) {
+	if len(data) < 9 {
+		return TokenTransfer{}, false
+	}
+
+	transfer = TokenTransfer{
+		InstructionIndex: index,
+		ProgramID:        programID,
+		Amount:           binary.LittleEndian.Uint64(data[1:9]),
+	}
+
+	switch data[0] {
+	case TOKEN_INSTRUCTION_TRANSFER:
+		if len(accounts) < 3 {
+			return TokenTransfer{}, false
+		}
+		transfer.Kind = "transfer"
+		transfer.Source, transfer.Destination, transfer.Authority = accounts[0], accounts[1], accounts[2]
+	case TOKEN_INSTRUCTION_TRANSFER_CHECKED:
+		if len(data) < 10 || len(accounts) < 4 {
+			return TokenTransfer{}, false
+		}
+		transfer.Kind = "transfer_checked"
+		transfer.Source, transfer.Mint, transfer.Destination, transfer.Authority = accounts[0], accounts[1], accounts[2], accounts[3]
+		transfer.Decimals = data[9]
+	case TOKEN_INSTRUCTION_MINT_TO, TOKEN_INSTRUCTION_MINT_TO_CHECKED:
+		if len(accounts) < 3 {
+			return TokenTransfer{}, false
+		}
+		transfer.Kind = "mint_to"
+		if data[0] == TOKEN_INSTRUCTION_MINT_TO_CHECKED {
+			if len(data) < 10 {
+				return TokenTransfer{}, false
+			}
+			transfer.Kind = "mint_to_checked"
+			transfer.Decimals = data[9]
+		}
+		transfer.Mint, transfer.Destination, transfer.Authority = accounts[0], accounts[1], accounts[2]
+	case TOKEN_INSTRUCTION_BURN, TOKEN_INSTRUCTION_BURN_CHECKED:
+		if len(accounts) < 3 {
+			return TokenTransfer{}, false
+		}
+		transfer.Kind = "burn"
+		if data[0] == TOKEN_INSTRUCTION_BURN_CHECKED {
+			if len(data) < 10 {
+				return TokenTransfer{}, false
+			}
+			transfer.Kind = "burn_checked"
+			transfer.Decimals = data[9]
+		}
+		transfer.Source, transfer.Mint, transfer.Authority = accounts[0], accounts[1], accounts[2]
+	case TOKEN_INSTRUCTION_TRANSFER_FEE_EXTENSION:
+		// TransferCheckedWithFee: amount, decimals and the fee the caller expects to be withheld
+		if data[1] != TRANSFER_FEE_TRANSFER_CHECKED_WITH_FEE || len(data) < 19 || len(accounts) < 4 || !programID.Equals(Token2022ProgramID) {
+			return TokenTransfer{}, false
+		}
+		transfer.Kind = "transfer_checked_with_fee"
+		transfer.Source, transfer.Mint, transfer.Destination, transfer.Authority = accounts[0], accounts[1], accounts[2], accounts[3]
+		transfer.Amount = binary.LittleEndian.Uint64(data[2:10])
+		transfer.Decimals = data[10]
+		transfer.Fee = binary.LittleEndian.Uint64(data[11:19])
+	default:
+		return TokenTransfer{}, false
+	}
+
+	return transfer, true
+}
+
+// decodeTokenProgram is the ProgramDecoder of the Token and Token-2022 programs
+func decodeTokenProgram(ctx *InstructionContext, result *Transaction) error {
+	ctx.parser().recordTokenInstruction(ctx.ProgramID, ctx.Data, ctx.Accounts, ctx.Index, result)
+	return nil
+}
+
+// recordTokenInstruction appends the transfer or mint of a Token or Token-2022 instruction to the result
+func (p *Parser) recordTokenInstruction(programID solana.PublicKey, data []byte, accounts []solana.PublicKey, index int, result *Transaction) {
+	transfer, ok := decodeTokenInstruction(programID, data, accounts, index)
+	if !ok {
+		return
+	}
+	p.logger.Printf("Token %s detected: %d tokens at instruction %d", transfer.Kind, transfer.Amount, index)
+	result.TokenTransfers = append(result.TokenTransfers, transfer)
+}
+
+// applyTokenTransfers completes the decoded transfers from the meta and takes the Token-2022 transfer
+// fees out of the amounts traders received. Unchecked transfers get their mint from the token balances.
+// The fee of a Token-2022 transfer that doesn't state it is what its destination received short of the
+// amount sent, as long as that destination takes part in no other transfer.
+func applyTokenTransfers(result *Transaction, accountKeys []solana.PublicKey, meta *TransactionMeta) {
+	if len(result.TokenTransfers) == 0 {
+		return
+	}
+
+	if meta != nil {
+		accountIndex := make(map[solana.PublicKey]int, len(accountKeys))
+		for i, key := range accountKeys {
+			accountIndex[key] = i
+		}
+		mints := make(map[int]solana.PublicKey)
+		pre := make(map[int]uint64)
+		post := make(map[int]uint64)
+		for _, balance := range meta.PreTokenBalances {
+			mints[balance.AccountIndex] = balance.Mint
+			pre[balance.AccountIndex] = balance.Amount
+		}
+		for _, balance := range meta.PostTokenBalances {
+			mints[balance.AccountIndex] = balance.Mint
+			post[balance.AccountIndex] = balance.Amount
+		}
+
+		uses := make(map[solana.PublicKey]int)
+		for _, transfer := range result.TokenTransfers {
+			uses[transfer.Source]++
+			uses[transfer.Destination]++
+		}
+
+		for i := range result.TokenTransfers {
+			transfer := &result.TokenTransfers[i]
+			destination, known := accountIndex[transfer.Destination]
+			if transfer.Mint.IsZero() && known {
+				transfer.Mint = mints[destination]
+			}
+
+			// Mints and burns don't pay transfer fees
+			if !transfer.ProgramID.Equals(Token2022ProgramID) || transfer.Fee != 0 || transfer.Source.IsZero() || transfer.Destination.IsZero() {
+				continue
+			}
+			if !known || uses[transfer.Destination] != 1 {
+				continue
+			}
+			if received := post[destination] - pre[destination]; post[destination] >= pre[destination] && received < transfer.Amount {
+				transfer.Fee = transfer.Amount - received
+			}
+		}
+	}
+
+	for i := range result.Trade {
+		trade := &result.Trade[i]
+		var fee uint64
+		for _, transfer := range result.TokenTransfers {
+			if transfer.InstructionIndex == trade.InstructionIndex && transfer.Mint.Equals(trade.TokenOut) {
+				fee += transfer.Fee
+			}
+		}
+		if fee == 0 || trade.AmountOut < fee {
+			continue
+		}
+
+		trade.TransferFee = fee
+		trade.AmountOut -= fee
+	}
+}
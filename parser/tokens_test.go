@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestToken2022TransfersInStandardPath(t *testing.T) {
+	owner := solana.NewWallet().PublicKey()
+	source := solana.NewWallet().PublicKey()
+	destination := solana.NewWallet().PublicKey()
+	feeDestination := solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+	keys := solana.PublicKeySlice{owner, source, destination, feeDestination, mint, Token2022ProgramID}
+
+	transferChecked := append(appendU64s([]byte{TOKEN_INSTRUCTION_TRANSFER_CHECKED}, 1000000), 6)
+	withFee := append(appendU64s([]byte{TOKEN_INSTRUCTION_TRANSFER_FEE_EXTENSION, TRANSFER_FEE_TRANSFER_CHECKED_WITH_FEE}, 500000), 6)
+	withFee = appendU64s(withFee, 2500)
+
+	encoded := encodeTransaction(t, keys, 2,
+		solana.CompiledInstruction{ProgramIDIndex: 5, Accounts: []uint16{1, 4, 2, 0}, Data: transferChecked},
+		solana.CompiledInstruction{ProgramIDIndex: 5, Accounts: []uint16{1, 4, 3, 0}, Data: withFee})
+
+	// The destination of the first transfer received 1% less than was sent
+	meta := &TransactionMeta{
+		PreTokenBalances:  []TokenBalance{{AccountIndex: 2, Mint: mint, Amount: 0}},
+		PostTokenBalances: []TokenBalance{{AccountIndex: 2, Mint: mint, Amount: 990000}},
+	}
+	result := mustParse(t, encoded, 0, meta)
+
+	if len(result.TokenTransfers) != 2 {
+		t.Fatalf("Expected 2 token transfers, got %d", len(result.TokenTransfers))
+	}
+	checked := result.TokenTransfers[0]
+	if checked.Kind != "transfer_checked" || !checked.Mint.Equals(mint) || checked.Amount != 1000000 || checked.Decimals != 6 {
+		t.Errorf("Unexpected transfer: %+v", checked)
+	}
+	if checked.Fee != 10000 {
+		t.Errorf("Expected a withheld fee of 10000, got %d", checked.Fee)
+	}
+	if fee := result.TokenTransfers[1]; fee.Kind != "transfer_checked_with_fee" || fee.Amount != 500000 || fee.Fee != 2500 {
+		t.Errorf("Unexpected transfer: %+v", fee)
+	}
+}
+
+func TestTransferFeeTakenFromAmountOut(t *testing.T) {
+	mint := solana.NewWallet().PublicKey()
+	result := &Transaction{
+		Trade: []TradeInfo{{InstructionIndex: 1, TokenIn: solana.SolMint, TokenOut: mint, AmountIn: 100, AmountOut: 5000}},
+		TokenTransfers: []TokenTransfer{
+			{InstructionIndex: 1, ProgramID: Token2022ProgramID, Mint: mint, Amount: 5000, Fee: 50},
+			{InstructionIndex: 2, ProgramID: Token2022ProgramID, Mint: mint, Amount: 5000, Fee: 50},
+		},
+	}
+	applyTokenTransfers(result, nil, nil)
+
+	if trade := result.Trade[0]; trade.AmountOut != 4950 || trade.TransferFee != 50 {
+		t.Errorf("Expected the fee of the trade's own instruction to be withheld, got %+v", trade)
+	}
+}
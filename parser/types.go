@@ -0,0 +1,190 @@
+package parser
+
+import (
+	"github.com/gagliardetto/solana-go"
+)
+
+// Transaction represents a parsed Solana transaction with Raydium-specific data
+type Transaction struct {
+	Signature  solana.Signature
+	Slot       uint64
+	BlockTime  int64            // Unix time of the block; 0 if unknown
+	ReceivedAt int64            // Unix time a Geyser update was created at by the node, not the block time; 0 for RPC transactions
+	FeePayer   solana.PublicKey // First account key; may differ from the trader on relayed transactions
+	Status     TransactionStatus
+	Err        *TransactionError // Why the transaction failed; nil unless Status is TransactionStatusFailed
+
+	ComputeBudget  ComputeBudget
+	JitoTip        uint64           // Lamports transferred to Jito tip accounts
+	JitoTipAccount solana.PublicKey // Tip account of the last tip; zero if none
+
+	// Every create, trade, migration and liquidity change in execution order; see Event
+	Events []Event
+
+	Create     []CreateInfo
+	Trade      []TradeInfo
+	TradeBuys  []int // Instruction indexes of the buys in Trade, derived from it
+	TradeSells []int // Instruction indexes of the sells in Trade, derived from it
+
+	Migrate   []Migration
+	SwapBuys  []SwapBuy  // The buys in Trade, derived from it
+	SwapSells []SwapSell // The sells in Trade, derived from it
+
+	// Liquidity deposited into and withdrawn from AMM v4 and CPMM pools
+	LiquidityAdds    []LiquidityAdd
+	LiquidityRemoves []LiquidityRemove
+
+	// Transfers and mints of the Token and Token-2022 programs
+	TokenTransfers []TokenTransfer
+
+	// Events emitted by the Launchpad program, decoded from emit_cpi instructions or logs
+	TradeEvents      []LaunchpadTradeEvent
+	PoolCreateEvents []LaunchpadPoolCreateEvent
+
+	// Instructions of known programs that couldn't be decoded; see DiagnosticsErr
+	Diagnostics []*ParseError
+}
+
+// CreateInfo represents token/pool creation information
+type CreateInfo struct {
+	TokenMint        solana.PublicKey
+	TokenDecimals    uint8
+	TokenSymbol      string
+	PoolAddress      solana.PublicKey
+	Creator          solana.PublicKey
+	Amount           uint64
+	Timestamp        int64
+	InstructionName  string           // Exact program instruction the event was decoded from
+	PlatformConfig   solana.PublicKey // Launchpad platform_config account; zero for other programs
+	Platform         string           // Label of PlatformConfig in the platform registry; "" if unknown
+	Failed           bool             // The transaction failed, so the pool was never created
+	InstructionIndex int
+	InnerIndex       int // Position among the inner instructions of InstructionIndex, -1 for top-level
+}
+
+// TradeInfo represents general trade information
+type TradeInfo struct {
+	InstructionIndex int
+	TokenIn          solana.PublicKey
+	TokenOut         solana.PublicKey
+	AmountIn         uint64
+	AmountOut        uint64
+	MinAmountOut     uint64 // Minimum amount out accepted by exact-in instructions; 0 if unknown or exact-out
+	MaxAmountIn      uint64 // Maximum amount in accepted by exact-out instructions; 0 if unknown or exact-in
+	Trader           solana.PublicKey
+	Pool             solana.PublicKey
+	TradeType        string           // "buy", "sell", "swap"
+	InstructionName  string           // Exact program instruction, e.g. "buy_exact_in"
+	InnerIndex       int              // Position among the inner instructions of InstructionIndex, -1 for top-level
+	StackHeight      int              // 1 for top-level, 2+ for CPI; 0 if unknown
+	Failed           bool             // The transaction failed, so the trade never settled
+	Timestamp        int64            // Block time of the transaction
+	TransferFee      uint64           // Token-2022 transfer fee withheld from AmountOut
+	PlatformConfig   solana.PublicKey // Launchpad platform_config account; zero for other programs
+	Platform         string           // Label of PlatformConfig in the platform registry; "" if unknown
+}
+
+// TokenTransfer is a transfer or mint decoded from a Token or Token-2022 instruction
+type TokenTransfer struct {
+	InstructionIndex int
+	ProgramID        solana.PublicKey
+	Kind             string           // "transfer", "transfer_checked", "transfer_checked_with_fee", "mint_to", "mint_to_checked", "burn", "burn_checked"
+	Source           solana.PublicKey // Zero for mints
+	Destination      solana.PublicKey // Zero for burns
+	Mint             solana.PublicKey // From the instruction, or the token balances for unchecked transfers
+	Authority        solana.PublicKey
+	Amount           uint64
+	Decimals         uint8  // Checked instructions only
+	Fee              uint64 // Token-2022 transfer fee withheld from Amount
+}
+
+// Migration represents a migration operation. For Launchpad graduations FromPool is the bonding curve
+// pool and ToPool the new AMM v4 or CPMM pool; amounts are what the new pool's vaults received,
+// 0 when parsed without meta.
+type Migration struct {
+	FromPool         solana.PublicKey
+	ToPool           solana.PublicKey
+	ToProgram        solana.PublicKey // RaydiumV4ProgramID or RaydiumCpSwapProgramID; zero for legacy decodes
+	Token            solana.PublicKey // Base mint
+	Amount           uint64           // Base tokens moved
+	QuoteMint        solana.PublicKey
+	QuoteAmount      uint64
+	LpMint           solana.PublicKey
+	LpAmount         uint64 // LP tokens burned or locked
+	LpBurned         bool
+	LpLocked         bool
+	Owner            solana.PublicKey
+	Timestamp        int64
+	InstructionName  string // Exact program instruction the event was decoded from
+	Failed           bool   // The transaction failed, so the pool never migrated
+	InstructionIndex int
+	InnerIndex       int // Position among the inner instructions of InstructionIndex, -1 for top-level
+}
+
+// LiquidityAdd is a deposit into a pool. Quote is the side that is a base currency (SOL, USDC, USDT);
+// amounts are what the pool vaults took in, 0 when parsed without meta.
+type LiquidityAdd struct {
+	InstructionIndex int
+	InnerIndex       int // Position among the inner instructions of InstructionIndex, -1 for top-level
+	Pool             solana.PublicKey
+	Provider         solana.PublicKey
+	BaseMint         solana.PublicKey
+	QuoteMint        solana.PublicKey
+	BaseAmount       uint64
+	QuoteAmount      uint64
+	LpMint           solana.PublicKey
+	LpMinted         uint64
+	PositionMint     solana.PublicKey // CLMM position NFT; zero for other pools
+	TickLower        int32            // CLMM tick range, known when the position is opened; 0 otherwise
+	TickUpper        int32
+	Failed           bool
+	Timestamp        int64
+	InstructionName  string // Exact program instruction the event was decoded from
+}
+
+// LiquidityRemove is a withdrawal from a pool. Amounts are what the pool vaults paid out, 0 when
+// parsed without meta; LpBurned comes from the instruction.
+type LiquidityRemove struct {
+	InstructionIndex int
+	InnerIndex       int // Position among the inner instructions of InstructionIndex, -1 for top-level
+	Pool             solana.PublicKey
+	Provider         solana.PublicKey
+	BaseMint         solana.PublicKey
+	QuoteMint        solana.PublicKey
+	BaseAmount       uint64
+	QuoteAmount      uint64
+	LpMint           solana.PublicKey
+	LpBurned         uint64
+	PositionMint     solana.PublicKey // CLMM position NFT; zero for other pools
+	Failed           bool
+	Timestamp        int64
+	InstructionName  string // Exact program instruction the event was decoded from
+}
+
+// SwapBuy represents a buy swap operation
+type SwapBuy struct {
+	TokenIn         solana.PublicKey
+	TokenOut        solana.PublicKey
+	AmountIn        uint64
+	AmountOut       uint64
+	MinAmountOut    uint64 // 0 for exact-out swaps
+	MaxAmountIn     uint64 // 0 for exact-in swaps
+	Pool            solana.PublicKey
+	Buyer           solana.PublicKey
+	Slippage        float64
+	InstructionName string // Exact program instruction the event was decoded from
+}
+
+// SwapSell represents a sell swap operation
+type SwapSell struct {
+	TokenIn         solana.PublicKey
+	TokenOut        solana.PublicKey
+	AmountIn        uint64
+	AmountOut       uint64
+	MinAmountOut    uint64 // 0 for exact-out swaps
+	MaxAmountIn     uint64 // 0 for exact-in swaps
+	Pool            solana.PublicKey
+	Seller          solana.PublicKey
+	Slippage        float64
+	InstructionName string // Exact program instruction the event was decoded from
+}
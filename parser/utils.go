@@ -1,8 +1,9 @@
-package main
+package parser
 
 import (
 	"encoding/hex"
 	"fmt"
+	"io"
 
 	"github.com/gagliardetto/solana-go"
 )
@@ -13,6 +14,7 @@ type TokenInfo struct {
 	Symbol   string
 	Name     string
 	Decimals uint8
+	Quote    bool // Trades are priced in this token: paying with it is a buy, receiving it a sell
 }
 
 // PoolInfo represents pool information
@@ -26,31 +28,42 @@ type PoolInfo struct {
 	Fee         uint64
 }
 
-// Known token mints and their information
-var knownTokens = map[string]TokenInfo{
-	"So11111111111111111111111111111111111111112": {
+// TokenRegistry holds the tokens the parser knows, by mint
+type TokenRegistry map[solana.PublicKey]TokenInfo
+
+// DefaultTokens holds SOL, USDC and USDT, the quote tokens of Raydium pools
+var DefaultTokens = TokenRegistry{
+	solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112"): {
 		Mint:     solana.MustPublicKeyFromBase58("So11111111111111111111111111111111111111112"),
 		Symbol:   "SOL",
 		Name:     "Solana",
 		Decimals: 9,
+		Quote:    true,
 	},
-	"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v": {
+	solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"): {
 		Mint:     solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"),
 		Symbol:   "USDC",
 		Name:     "USD Coin",
 		Decimals: 6,
+		Quote:    true,
 	},
-	"Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB": {
+	solana.MustPublicKeyFromBase58("Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"): {
 		Mint:     solana.MustPublicKeyFromBase58("Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB"),
 		Symbol:   "USDT",
 		Name:     "Tether USD",
 		Decimals: 6,
+		Quote:    true,
 	},
 }
 
+// IsQuote reports whether trades are priced in a token
+func (r TokenRegistry) IsQuote(mint solana.PublicKey) bool {
+	return r[mint].Quote
+}
+
 // GetTokenInfo retrieves token information by mint address
 func GetTokenInfo(mint solana.PublicKey) TokenInfo {
-	if info, exists := knownTokens[mint.String()]; exists {
+	if info, exists := DefaultTokens[mint]; exists {
 		return info
 	}
 
@@ -127,19 +140,19 @@ func ExtractInstructionData(data []byte) map[string]interface{} {
 	return result
 }
 
-// AnalyzeTransaction provides detailed analysis of a transaction
-func AnalyzeTransaction(tx *Transaction) {
-	fmt.Println("=== Transaction Analysis ===")
+// AnalyzeTransaction writes a summary of what a transaction does to w
+func AnalyzeTransaction(w io.Writer, tx *Transaction) {
+	fmt.Fprintln(w, "=== Transaction Analysis ===")
 
 	// Analyze transaction type
 	if len(tx.Create) > 0 {
-		fmt.Println("🏗️  Pool/Token Creation Transaction")
+		fmt.Fprintln(w, "🏗️  Pool/Token Creation Transaction")
 	}
 	if len(tx.Trade) > 0 {
-		fmt.Println("💱 Trading Transaction")
+		fmt.Fprintln(w, "💱 Trading Transaction")
 	}
 	if len(tx.Migrate) > 0 {
-		fmt.Println("🔄 Migration Transaction")
+		fmt.Fprintln(w, "🔄 Migration Transaction")
 	}
 
 	// Analyze trading activity
@@ -147,7 +160,7 @@ func AnalyzeTransaction(tx *Transaction) {
 	totalSells := len(tx.SwapSells)
 
 	if totalBuys > 0 || totalSells > 0 {
-		fmt.Printf("📊 Trading Activity: %d buys, %d sells\n", totalBuys, totalSells)
+		fmt.Fprintf(w, "📊 Trading Activity: %d buys, %d sells\n", totalBuys, totalSells)
 	}
 
 	// Analyze tokens involved
@@ -158,15 +171,15 @@ func AnalyzeTransaction(tx *Transaction) {
 	}
 
 	if len(tokensInvolved) > 0 {
-		fmt.Printf("🪙 Tokens involved: %d unique tokens\n", len(tokensInvolved))
+		fmt.Fprintf(w, "🪙 Tokens involved: %d unique tokens\n", len(tokensInvolved))
 		for tokenAddr := range tokensInvolved {
 			mint := solana.MustPublicKeyFromBase58(tokenAddr)
 			tokenInfo := GetTokenInfo(mint)
-			fmt.Printf("   - %s (%s)\n", tokenInfo.Symbol, tokenInfo.Name)
+			fmt.Fprintf(w, "   - %s (%s)\n", tokenInfo.Symbol, tokenInfo.Name)
 		}
 	}
 
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
 // ValidateTransaction performs basic validation on a parsed transaction
@@ -183,6 +196,18 @@ func ValidateTransaction(tx *Transaction) []string {
 		issues = append(issues, "Transaction has zero slot number")
 	}
 
+	// Report the execution result
+	switch tx.Status {
+	case TransactionStatusFailed:
+		if tx.Err != nil {
+			issues = append(issues, fmt.Sprintf("Transaction failed: %s", tx.Err))
+		} else {
+			issues = append(issues, "Transaction failed")
+		}
+	case TransactionStatusUnknown:
+		issues = append(issues, "Transaction status is unknown (no meta)")
+	}
+
 	// Validate trade consistency
 	if len(tx.TradeBuys) != len(tx.SwapBuys) {
 		issues = append(issues, "Mismatch between trade buys count and swap buys count")
@@ -205,15 +230,15 @@ func ValidateTransaction(tx *Transaction) []string {
 	return issues
 }
 
-// PrintValidationResults prints validation results
-func PrintValidationResults(issues []string) {
+// PrintValidationResults writes the issues ValidateTransaction found to w
+func PrintValidationResults(w io.Writer, issues []string) {
 	if len(issues) == 0 {
-		fmt.Println("✅ Transaction validation passed")
+		fmt.Fprintln(w, "✅ Transaction validation passed")
 		return
 	}
 
-	fmt.Printf("⚠️  Transaction validation found %d issues:\n", len(issues))
+	fmt.Fprintf(w, "⚠️  Transaction validation found %d issues:\n", len(issues))
 	for i, issue := range issues {
-		fmt.Printf("   %d. %s\n", i+1, issue)
+		fmt.Fprintf(w, "   %d. %s\n", i+1, issue)
 	}
 }
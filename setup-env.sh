@@ -59,7 +59,7 @@ echo ""
 echo "Quick start:"
 echo "  ./raydium-parser-env help    # Show help"
 echo "  ./raydium-parser-env test    # Run instruction builder tests"
-echo "  go test -v -run TestSwapInstructionBuilder  # Run specific test"
+echo "  go test -v ./parser -run TestSwapInstructionBuilder  # Run specific test"
 echo ""
 echo "For detailed instructions, see ENVIRONMENT.md"
 echo ""